@@ -0,0 +1,134 @@
+package pac194x5x
+
+import (
+	"context"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+)
+
+const (
+	// alertPollTimeout bounds how long WatchAlerts waits for an edge before checking for cancellation.
+	alertPollTimeout = 100 * time.Millisecond
+)
+
+// AlertEvent represents an alert raised by the device.
+type AlertEvent struct {
+	Time    time.Time // Time the alert status was read.
+	Type    AlertType // Type of alert.
+	Addr    uint16    // Addr is the I2C address of the device.
+	Channel int       // Channel number, or -1 for device-wide alerts.
+	Status  Alerts    // Status is the complete alert status the event was decoded from.
+	Err     error     // Err is set if the alert status could not be read. Only Time and Addr are set with it.
+}
+
+var channelAlerts = []struct {
+	alertType AlertType
+	bits      [4]Alerts
+}{
+	{AlertTypeOverCurrent, [4]Alerts{AlertOC1, AlertOC2, AlertOC3, AlertOC4}},
+	{AlertTypeUnderCurrent, [4]Alerts{AlertUC1, AlertUC2, AlertUC3, AlertUC4}},
+	{AlertTypeOverVoltage, [4]Alerts{AlertOV1, AlertOV2, AlertOV3, AlertOV4}},
+	{AlertTypeUnderVoltage, [4]Alerts{AlertUV1, AlertUV2, AlertUV3, AlertUV4}},
+	{AlertTypeOverPower, [4]Alerts{AlertOP1, AlertOP2, AlertOP3, AlertOP4}},
+}
+
+// GetAlertStatus returns the Alert_Status register value. Reading the register clears it.
+func (dev *Dev) GetAlertStatus() (Alerts, error) {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	return dev.cache.alertStatus.Read(dev)
+}
+
+// GetAlertEnable returns the Alert_Enable register value.
+func (dev *Dev) GetAlertEnable() (Alerts, error) {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	return dev.cache.alertEnable.Read(dev)
+}
+
+// SetAlertEnable sets the Alert_Enable register value.
+func (dev *Dev) SetAlertEnable(v Alerts) error {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	return dev.cache.alertEnable.Write(dev, v)
+}
+
+// WatchAlerts arms falling edge detection on pin, which must be wired to the ALERT1 or ALERT2 output. On every
+// edge the alert status is read, which clears it and releases the ALERT output, and decoded into events. The
+// returned channel is closed when ctx is cancelled.
+func (dev *Dev) WatchAlerts(ctx context.Context, pin gpio.PinIn) (<-chan AlertEvent, error) {
+	err := pin.In(gpio.PullUp, gpio.FallingEdge)
+	if err != nil {
+		return nil, err
+	}
+
+	return watchAlertPin(ctx, pin, dev.readAlertEvents), nil
+}
+
+// watchAlertPin calls read on every falling edge of pin, which must already be armed, and delivers the events
+// it returns. The returned channel is closed when ctx is cancelled.
+func watchAlertPin(ctx context.Context, pin gpio.PinIn, read func() []AlertEvent) <-chan AlertEvent {
+	events := make(chan AlertEvent, 16)
+	go func() {
+		defer close(events)
+		for ctx.Err() == nil {
+			// The ALERT output stays low while any status bit is set, so an edge missed between reads is
+			// caught by checking the level.
+			if !pin.WaitForEdge(alertPollTimeout) && (pin.Read() == gpio.High) {
+				continue
+			}
+			for _, event := range read() {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return events
+}
+
+func (dev *Dev) readAlertEvents() []AlertEvent {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	t := time.Now()
+	status, err := dev.GetAlertStatus()
+	if err != nil {
+		return []AlertEvent{{Time: t, Addr: dev.i2cDev.Addr, Channel: -1, Err: err}}
+	}
+	if status&AlertAccOverflow != 0 {
+		dev.accOverflowAlert = true
+	}
+	return dev.decodeAlerts(t, status)
+}
+
+// decodeAlerts decodes status into one event per raised alert.
+func (dev *Dev) decodeAlerts(t time.Time, status Alerts) []AlertEvent {
+	var events []AlertEvent
+	event := func(alertType AlertType, channelNo int) AlertEvent {
+		return AlertEvent{Time: t, Type: alertType, Addr: dev.i2cDev.Addr, Channel: channelNo, Status: status}
+	}
+	for _, channelAlert := range channelAlerts {
+		for channelNo := range dev.channelCount {
+			if status&channelAlert.bits[channelNo] != 0 {
+				events = append(events, event(channelAlert.alertType, channelNo))
+			}
+		}
+	}
+	if status&AlertAccCount != 0 {
+		events = append(events, event(AlertTypeAccCount, -1))
+	}
+	if status&AlertAccOverflow != 0 {
+		events = append(events, event(AlertTypeAccOverflow, -1))
+	}
+	if status&(AlertConversionComplete1|AlertConversionComplete2) != 0 {
+		events = append(events, event(AlertTypeConversionComplete, -1))
+	}
+	return events
+}
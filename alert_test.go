@@ -0,0 +1,276 @@
+package pac194x5x
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpiotest"
+	"periph.io/x/conn/v3/physic"
+)
+
+// fakeBus emulates the registers of a single device. Registers that were never set read as zero.
+type fakeBus struct {
+	mu          sync.Mutex
+	regs        map[uint8][]byte
+	reads       map[uint8]int
+	writes      map[uint8]int
+	err         error
+	onAlertRead func()
+}
+
+func newFakeBus(productID ProductID) *fakeBus {
+	return &fakeBus{
+		regs: map[uint8][]byte{
+			ProductIDRegister.Address: {uint8(productID)},
+		},
+		reads:  make(map[uint8]int),
+		writes: make(map[uint8]int),
+	}
+}
+
+func (b *fakeBus) String() string {
+	return "fake"
+}
+
+func (b *fakeBus) SetSpeed(_ physic.Frequency) error {
+	return nil
+}
+
+func (b *fakeBus) Tx(_ uint16, w []byte, r []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(w) == 0 {
+		return b.err
+	}
+	address := w[0]
+	if len(r) == 0 {
+		b.writes[address]++
+	} else {
+		b.reads[address]++
+	}
+	if b.err != nil {
+		return b.err
+	}
+	if len(r) == 0 {
+		if len(w) > 1 {
+			b.regs[address] = append([]byte(nil), w[1:]...)
+		}
+		return nil
+	}
+	clear(r)
+	copy(r, b.regs[address])
+	if address == AlertStatusRegister.Address {
+		delete(b.regs, address)
+		if b.onAlertRead != nil {
+			b.onAlertRead()
+		}
+	}
+	return nil
+}
+
+func (b *fakeBus) setAlertStatus(status Alerts) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	data, _ := AlertsCodec.Marshal(status)
+	b.regs[AlertStatusRegister.Address] = data
+}
+
+func (b *fakeBus) setRegister(address uint8, data ...byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.regs[address] = data
+}
+
+func (b *fakeBus) readCount(address uint8) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.reads[address]
+}
+
+func (b *fakeBus) writeCount(address uint8) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.writes[address]
+}
+
+func (b *fakeBus) setErr(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.err = err
+}
+
+// newAlertTestDev creates a two-channel Dev on a fake bus and a pin that is released when the alert status is read.
+func newAlertTestDev(t *testing.T) (*Dev, *fakeBus, *gpiotest.Pin) {
+	t.Helper()
+
+	bus := newFakeBus(PAC1942_1)
+	dev, err := NewI2C(bus, 0x10, []float64{1, 1}, []float64{0.01, 0.01})
+	if err != nil {
+		t.Fatalf("NewI2C() error = %v", err)
+	}
+	pin := &gpiotest.Pin{N: "ALERT", EdgesChan: make(chan gpio.Level, 1)}
+	bus.onAlertRead = func() {
+		_ = pin.Out(gpio.High)
+	}
+	return dev, bus, pin
+}
+
+func receiveEvents(t *testing.T, events <-chan AlertEvent, n int) []AlertEvent {
+	t.Helper()
+
+	var received []AlertEvent
+	for range n {
+		select {
+		case event := <-events:
+			received = append(received, event)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("received %d events, want %d", len(received), n)
+		}
+	}
+	return received
+}
+
+func TestWatchAlertsEdge(t *testing.T) {
+	dev, bus, pin := newAlertTestDev(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := dev.WatchAlerts(ctx, pin)
+	if err != nil {
+		t.Fatalf("WatchAlerts() error = %v", err)
+	}
+	if pin.Pull() != gpio.PullUp {
+		t.Errorf("pin pull = %v, want %v", pin.Pull(), gpio.PullUp)
+	}
+
+	status := AlertOC2 | AlertUV1 | AlertAccOverflow
+	bus.setAlertStatus(status)
+	pin.EdgesChan <- gpio.Low
+
+	received := receiveEvents(t, events, 3)
+	want := []struct {
+		alertType AlertType
+		channelNo int
+	}{
+		{AlertTypeOverCurrent, 1},
+		{AlertTypeUnderVoltage, 0},
+		{AlertTypeAccOverflow, -1},
+	}
+	for i, event := range received {
+		if (event.Type != want[i].alertType) || (event.Channel != want[i].channelNo) {
+			t.Errorf("event %d = type %d channel %d, want type %d channel %d",
+				i, event.Type, event.Channel, want[i].alertType, want[i].channelNo)
+		}
+		if event.Status != status {
+			t.Errorf("event %d status = %#06x, want %#06x", i, event.Status, status)
+		}
+		if event.Addr != 0x10 {
+			t.Errorf("event %d addr = %#02x, want 0x10", i, event.Addr)
+		}
+		if event.Err != nil {
+			t.Errorf("event %d error = %v", i, event.Err)
+		}
+	}
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Error("unexpected event after cancel")
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("events not closed after cancel")
+	}
+}
+
+func TestWatchAlertsLevel(t *testing.T) {
+	dev, bus, pin := newAlertTestDev(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := dev.WatchAlerts(ctx, pin)
+	if err != nil {
+		t.Fatalf("WatchAlerts() error = %v", err)
+	}
+
+	// The edge was missed, but the line is still held low.
+	bus.setAlertStatus(AlertOP1)
+	_ = pin.Out(gpio.Low)
+
+	received := receiveEvents(t, events, 1)
+	if (received[0].Type != AlertTypeOverPower) || (received[0].Channel != 0) {
+		t.Errorf("event = type %d channel %d, want type %d channel 0",
+			received[0].Type, received[0].Channel, AlertTypeOverPower)
+	}
+}
+
+func TestWatchAlertsReadError(t *testing.T) {
+	dev, bus, pin := newAlertTestDev(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := dev.WatchAlerts(ctx, pin)
+	if err != nil {
+		t.Fatalf("WatchAlerts() error = %v", err)
+	}
+
+	errBus := errors.New("bus error")
+	bus.setErr(errBus)
+	pin.EdgesChan <- gpio.Low
+
+	received := receiveEvents(t, events, 1)
+	if !errors.Is(received[0].Err, errBus) {
+		t.Errorf("event error = %v, want %v", received[0].Err, errBus)
+	}
+	if received[0].Channel != -1 {
+		t.Errorf("event channel = %d, want -1", received[0].Channel)
+	}
+}
+
+func TestWatchAlertsConcurrentSnapshot(t *testing.T) {
+	dev, bus, pin := newAlertTestDev(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := dev.WatchAlerts(ctx, pin)
+	if err != nil {
+		t.Fatalf("WatchAlerts() error = %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 50 {
+			_, err := dev.ReadSnapshot()
+			if err != nil {
+				t.Errorf("ReadSnapshot() error = %v", err)
+				return
+			}
+		}
+	}()
+
+	bus.setAlertStatus(AlertAccOverflow)
+	pin.EdgesChan <- gpio.Low
+	receiveEvents(t, events, 1)
+	<-done
+
+	snapshot, err := dev.ReadSnapshot()
+	if err != nil {
+		t.Fatalf("ReadSnapshot() error = %v", err)
+	}
+	for _, cs := range snapshot.Channels {
+		if cs.Flags&FlagAccOverflow == 0 {
+			t.Errorf("channel %d flags = %#x, want FlagAccOverflow", cs.Channel, cs.Flags)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package pac194x5x
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// AutoRangeConfig configures an AutoRanger.
+type AutoRangeConfig struct {
+	UpThreshold         float64       // UpThreshold is the fraction of the half range above which the full range is selected, e.g. 0.9.
+	DownThreshold       float64       // DownThreshold is the fraction of the half range below which the half range is selected, e.g. 0.7.
+	AllowUnipolarVSense bool          // AllowUnipolarVSense selects the unipolar VSENSE range, for channels whose current is never negative.
+	AllowUnipolarVBus   bool          // AllowUnipolarVBus selects the unipolar VBUS range, for channels whose voltage is never negative.
+	Delay               time.Duration // Delay after the Refresh that latches a new range. Zero means DefaultDelay.
+}
+
+// AutoRanger selects the NEG_PWR_FSR ranges of a channel that give the best resolution without clipping.
+//
+// Bipolar quantities switch between the full and the half range, with hysteresis between UpThreshold and
+// DownThreshold. A quantity allowed to be unipolar always uses the unipolar range, which has the resolution of
+// the half range and the span of the full range for non-negative values. A range change is latched with a
+// Refresh, which also resets the accumulators so that they never mix ranges. The range each reading was taken
+// with is recorded in ChannelSnapshot. AutoRanger is safe for concurrent use.
+type AutoRanger struct {
+	dev       *Dev
+	channelNo int
+	config    AutoRangeConfig
+
+	mu sync.Mutex
+}
+
+// NewAutoRanger creates an AutoRanger for channelNo of dev. The thresholds must satisfy
+// 0 < DownThreshold < UpThreshold <= 1, and Delay must not be negative.
+func NewAutoRanger(dev *Dev, channelNo int, config AutoRangeConfig) (*AutoRanger, error) {
+	err := dev.checkChannelNo(channelNo)
+	if err != nil {
+		return nil, err
+	}
+	if !((0 < config.DownThreshold) && (config.DownThreshold < config.UpThreshold) && (config.UpThreshold <= 1)) {
+		return nil, fmt.Errorf("invalid auto-range thresholds: down %v, up %v", config.DownThreshold, config.UpThreshold)
+	}
+	if config.Delay < 0 {
+		return nil, fmt.Errorf("invalid auto-range delay: %v", config.Delay)
+	}
+	if config.Delay == 0 {
+		config.Delay = DefaultDelay
+	}
+	return &AutoRanger{
+		dev:       dev,
+		channelNo: channelNo,
+		config:    config,
+	}, nil
+}
+
+// Update selects the ranges based on the channel's readings in snapshot, and latches them if they changed. It
+// returns true if the ranges changed.
+func (ar *AutoRanger) Update(snapshot Snapshot) (bool, error) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	var cs *ChannelSnapshot
+	for i := range snapshot.Channels {
+		if snapshot.Channels[i].Channel == ar.channelNo {
+			cs = &snapshot.Channels[i]
+		}
+	}
+	// A disabled channel has no readings and no latched ranges to compare against.
+	if (cs == nil) || (cs.Flags&FlagDisabled != 0) || math.IsNaN(cs.VSense) || math.IsNaN(cs.VBus) {
+		return false, nil
+	}
+
+	changed := false
+	err := ar.dev.Transaction(func(dev *Dev) error {
+		voltageRatio := dev.voltageRatio[ar.channelNo]
+		vBusFullScale := 9.0
+		if dev.isPAC5x {
+			vBusFullScale = 32.0
+		}
+		vSenseRange := ar.selectRange(cs.VSenseRange, cs.VSense, 100, ar.config.AllowUnipolarVSense)
+		vBusRange := ar.selectRange(cs.VBusRange, cs.VBus*voltageRatio, vBusFullScale, ar.config.AllowUnipolarVBus)
+		if (vSenseRange == cs.VSenseRange) && (vBusRange == cs.VBusRange) {
+			return nil
+		}
+
+		negPwrFsr, err := dev.GetNegPwrFsr()
+		if err != nil {
+			return err
+		}
+		negPwrFsr = SetField(negPwrFsr, VSenseRangeFields[ar.channelNo], uint16(vSenseRange))
+		negPwrFsr = SetField(negPwrFsr, VBusRangeFields[ar.channelNo], uint16(vBusRange))
+		err = dev.SetNegPwrFsr(negPwrFsr)
+		if err != nil {
+			return err
+		}
+		changed = true
+		return dev.Refresh(ar.config.Delay)
+	})
+	if err != nil {
+		return false, err
+	}
+	return changed, nil
+}
+
+// selectRange returns the range for value, measured with current, of a quantity with the specified full scale.
+func (ar *AutoRanger) selectRange(current Range, value float64, fullScale float64, allowUnipolar bool) Range {
+	if allowUnipolar {
+		return RangeUnipolar
+	}
+	halfScale := fullScale / 2
+	magnitude := math.Abs(value)
+	switch current {
+	case RangeBipolarHalf:
+		if magnitude > ar.config.UpThreshold*halfScale {
+			return RangeBipolar
+		}
+		return RangeBipolarHalf
+	case RangeBipolar:
+		if magnitude < ar.config.DownThreshold*halfScale {
+			return RangeBipolarHalf
+		}
+		return RangeBipolar
+	default:
+		// A unipolar range clips negative values, so start from the full range.
+		return RangeBipolar
+	}
+}
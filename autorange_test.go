@@ -0,0 +1,76 @@
+package pac194x5x
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAutoRangerDisabledChannel(t *testing.T) {
+	bus := newFakeBus(PAC1942_1)
+	dev, err := NewI2C(bus, 0x10, []float64{1, 1}, []float64{0.01, 0.01})
+	if err != nil {
+		t.Fatalf("NewI2C() error = %v", err)
+	}
+	ctrlLat, _ := Uint16Codec.Marshal(SetField(uint16(0), ChannelOffFields[1], 1))
+	bus.setRegister(CtrlLatRegister.Address, ctrlLat...)
+
+	ar, err := NewAutoRanger(dev, 1, AutoRangeConfig{UpThreshold: 0.9, DownThreshold: 0.7, Delay: 1})
+	if err != nil {
+		t.Fatalf("NewAutoRanger() error = %v", err)
+	}
+	accReset := dev.GetAccReset()
+	for range 3 {
+		snapshot, err := dev.ReadSnapshot()
+		if err != nil {
+			t.Fatalf("ReadSnapshot() error = %v", err)
+		}
+		changed, err := ar.Update(snapshot)
+		if err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		if changed {
+			t.Error("Update() changed the ranges of a disabled channel")
+		}
+	}
+	if n := bus.writeCount(RefreshRegister.Address); n != 0 {
+		t.Errorf("REFRESH writes = %d, want 0", n)
+	}
+	if n := bus.writeCount(NegPwrFsrRegister.Address); n != 0 {
+		t.Errorf("NEG_PWR_FSR writes = %d, want 0", n)
+	}
+	if got := dev.GetAccReset(); got != accReset {
+		t.Errorf("GetAccReset() = %d, want %d", got, accReset)
+	}
+}
+
+func TestNewAutoRangerThresholds(t *testing.T) {
+	dev, err := NewI2C(newFakeBus(PAC1942_1), 0x10, []float64{1, 1}, []float64{0.01, 0.01})
+	if err != nil {
+		t.Fatalf("NewI2C() error = %v", err)
+	}
+	tests := []struct {
+		down    float64
+		up      float64
+		wantErr bool
+	}{
+		{0.7, 0.9, false},
+		{0.5, 1, false},
+		{0, 0, true},
+		{0, 0.9, true},
+		{0.9, 0.7, true},
+		{0.8, 0.8, true},
+		{0.7, 1.1, true},
+		{-0.1, 0.9, true},
+		{math.NaN(), 0.9, true},
+	}
+	for _, tt := range tests {
+		_, err := NewAutoRanger(dev, 0, AutoRangeConfig{UpThreshold: tt.up, DownThreshold: tt.down})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NewAutoRanger(down %v, up %v) error = %v, wantErr %v", tt.down, tt.up, err, tt.wantErr)
+		}
+	}
+	_, err = NewAutoRanger(dev, 0, AutoRangeConfig{UpThreshold: 0.9, DownThreshold: 0.7, Delay: -1})
+	if err == nil {
+		t.Error("NewAutoRanger() with negative delay succeeded")
+	}
+}
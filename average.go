@@ -0,0 +1,106 @@
+package pac194x5x
+
+import (
+	"math"
+	"time"
+)
+
+// AccumulatedAverage represents the average of the accumulated values of a channel since the last Refresh.
+type AccumulatedAverage struct {
+	Value  float64       // Value is the average power in W, VSENSE in mV or VBUS in V, depending on Mode.
+	Unit   UnitType      // Unit of Value.
+	Mode   AccumMode     // Mode is the latched accumulation mode of the channel.
+	Count  uint32        // Count is the number of accumulated samples.
+	Window time.Duration // Window is the duration covered by the samples, or 0 if the sample rate is not fixed.
+}
+
+// GetAccumulatedAverage calculates the average since the last Refresh using the Vacc_N and Acc_Count registers.
+// Value is NaN if no sample has been accumulated.
+func (dev *Dev) GetAccumulatedAverage(channelNo int) (AccumulatedAverage, error) {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.checkChannelActive(channelNo)
+	if err != nil {
+		return AccumulatedAverage{}, err
+	}
+
+	accumConfig, err := dev.getChAccumConfig(channelNo)
+	if err != nil {
+		return AccumulatedAverage{}, err
+	}
+
+	bidirV, _, err := dev.getBidirFsrVLat(channelNo)
+	if err != nil {
+		return AccumulatedAverage{}, err
+	}
+	bidirI, _, err := dev.getBidirFsrILat(channelNo)
+	if err != nil {
+		return AccumulatedAverage{}, err
+	}
+
+	average := AccumulatedAverage{
+		Mode: AccumMode(accumConfig),
+	}
+	var bidir bool
+	var lsb float64
+	ratio := 1.0
+	switch average.Mode {
+	case AccumModeVPower:
+		bidir = bidirV || bidirI
+		lsb, err = dev.getPowerUnit(channelNo)
+		average.Unit = Watts
+		ratio = dev.voltageRatio[channelNo]
+	case AccumModeVSense:
+		bidir = bidirI
+		lsb, err = dev.getVSenseLSB(channelNo)
+		average.Unit = MilliVolts
+	case AccumModeVBus:
+		bidir = bidirV
+		lsb, err = dev.getVBusLSB(channelNo)
+		average.Unit = Volts
+		ratio = dev.voltageRatio[channelNo]
+	default:
+		average.Value = math.NaN()
+		average.Unit = Unknown
+		return average, nil
+	}
+	if err != nil {
+		return AccumulatedAverage{}, err
+	}
+
+	average.Count, err = dev.GetAccCount()
+	if err != nil {
+		return AccumulatedAverage{}, err
+	}
+
+	v, err := dev.cache.vAcc[channelNo].Read(dev)
+	if err != nil {
+		return AccumulatedAverage{}, err
+	}
+
+	sampleFrequency, err := dev.getSampleFrequency()
+	if err != nil {
+		return AccumulatedAverage{}, err
+	}
+	if !math.IsNaN(sampleFrequency) {
+		average.Window = time.Duration(float64(average.Count) / sampleFrequency * float64(time.Second))
+	}
+
+	if average.Count == 0 {
+		average.Value = math.NaN()
+		return average, nil
+	}
+
+	code, err := decodeSigned(v, bidir, Uint64Codec, Int56Codec)
+	if err != nil {
+		return AccumulatedAverage{}, err
+	}
+	raw := float64(code)
+	sum, err := dev.calibrateAccumulated(channelNo, average.Mode, raw*lsb/ratio, average.Count)
+	if err != nil {
+		return AccumulatedAverage{}, err
+	}
+	average.Value = sum / float64(average.Count)
+	return average, nil
+}
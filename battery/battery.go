@@ -0,0 +1,259 @@
+// Package battery implements a simple state-of-charge estimator for a battery pack monitored by a PAC194x5x
+// channel in VSENSE accumulation mode.
+//
+// Positive current is discharge. The estimator counts charge from the accumulator, recalibrates to 100% when the
+// pack is full and to the open-circuit voltage table when the pack is at rest, and learns the usable capacity
+// from the net charge removed between full and empty, so partial recharges on the way are subtracted.
+package battery
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ngyewch/pac194x5x"
+)
+
+// OCVPoint maps an open-circuit voltage to a state of charge.
+type OCVPoint struct {
+	Voltage float64 // Voltage in V.
+	SoC     float64 // SoC from 0 to 1.
+}
+
+// Config defines the battery pack.
+type Config struct {
+	Capacity     float64       // Capacity is the design capacity in mAh.
+	OCV          []OCVPoint    // OCV is the open-circuit voltage table.
+	FullVoltage  float64       // FullVoltage in V. The pack is full at or above it while the current tapers below TaperCurrent.
+	EmptyVoltage float64       // EmptyVoltage in V. The pack is empty at or below it.
+	TaperCurrent float64       // TaperCurrent is the end-of-charge current in mA.
+	RestCurrent  float64       // RestCurrent in mA. The pack is at rest while the absolute current is below it.
+	RestTime     time.Duration // RestTime is the time at rest after which the voltage is taken as the open-circuit voltage.
+}
+
+// State holds the learned parameters to be persisted between runs.
+type State struct {
+	LearnedCapacity float64 `json:"learnedCapacity"` // LearnedCapacity in mAh, or 0 if not learned yet.
+}
+
+// Status represents the estimated state of the pack.
+type Status struct {
+	SoC         float64       // SoC from 0 to 1.
+	Remaining   float64       // Remaining charge in mAh.
+	Capacity    float64       // Capacity in use in mAh.
+	Voltage     float64       // Voltage in V.
+	Current     float64       // Current in mA. Positive is discharge.
+	TimeToEmpty time.Duration // TimeToEmpty at the present current, or 0 if not discharging.
+	TimeToFull  time.Duration // TimeToFull at the present current, or 0 if not charging.
+	Full        bool          // Full is true if the pack was detected as full.
+	Empty       bool          // Empty is true if the pack was detected as empty.
+	Resting     bool          // Resting is true if the pack has been at rest for RestTime.
+}
+
+// Estimator estimates the state of charge of a pack.
+//
+// Update must be called after every refresh of the device. Refresh_V is recommended so the accumulator keeps
+// counting. A reset of the accumulator is detected from Dev.GetAccReset, and from a decrease of ACC_COUNT for
+// resets not sent through the Dev. Estimator is safe for concurrent use.
+type Estimator struct {
+	dev       *pac194x5x.Dev
+	channelNo int
+	config    Config
+
+	mu           sync.Mutex
+	state        State
+	started      bool
+	remaining    float64
+	lastCharge   float64
+	lastAccCount uint32
+	lastAccReset uint64
+	restSince    time.Time
+	fullSeen     bool
+	removed      float64 // removed is the net charge in mAh removed since the pack was last full.
+}
+
+// New creates an Estimator for channelNo of dev, which must be in VSENSE accumulation mode. state holds the
+// parameters learned in a previous run.
+func New(dev *pac194x5x.Dev, channelNo int, config Config, state State) (*Estimator, error) {
+	if config.Capacity <= 0 {
+		return nil, fmt.Errorf("invalid capacity: %f", config.Capacity)
+	}
+	if len(config.OCV) < 2 {
+		return nil, fmt.Errorf("OCV table needs at least 2 points, got %d", len(config.OCV))
+	}
+	config.OCV = slices.Clone(config.OCV)
+	slices.SortFunc(config.OCV, func(a, b OCVPoint) int {
+		return cmp.Compare(a.Voltage, b.Voltage)
+	})
+	for i := 1; i < len(config.OCV); i++ {
+		if config.OCV[i].Voltage == config.OCV[i-1].Voltage {
+			return nil, fmt.Errorf("duplicate OCV voltage: %f", config.OCV[i].Voltage)
+		}
+	}
+	if config.FullVoltage <= config.EmptyVoltage {
+		return nil, fmt.Errorf("full voltage %f not above empty voltage %f", config.FullVoltage, config.EmptyVoltage)
+	}
+	if config.RestTime <= 0 {
+		return nil, fmt.Errorf("invalid rest time: %v", config.RestTime)
+	}
+	return &Estimator{
+		dev:       dev,
+		channelNo: channelNo,
+		config:    config,
+		state:     state,
+	}, nil
+}
+
+// State returns the learned parameters.
+func (e *Estimator) State() State {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.state
+}
+
+// Update reads the channel and returns the updated estimate.
+func (e *Estimator) Update() (Status, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var accCount uint32
+	var accReset uint64
+	var charge pac194x5x.Charge
+	var voltage, current float64
+	err := e.dev.Transaction(func(dev *pac194x5x.Dev) error {
+		var err error
+		accCount, err = dev.GetAccCount()
+		if err != nil {
+			return err
+		}
+		accReset = dev.GetAccReset()
+		charge, err = dev.GetCharge(e.channelNo)
+		if err != nil {
+			return err
+		}
+		voltage, err = dev.GetVBus(e.channelNo)
+		if err != nil {
+			return err
+		}
+		current, err = dev.GetCurrentAvg(e.channelNo)
+		return err
+	})
+	if err != nil {
+		return Status{}, err
+	}
+	if math.IsNaN(charge.MilliAmpHours) {
+		return Status{}, fmt.Errorf("channel %d is not in VSENSE accumulation mode", e.channelNo)
+	}
+
+	now := time.Now()
+	capacity := e.capacity()
+	status := Status{
+		Capacity: capacity,
+		Voltage:  voltage,
+		Current:  current,
+	}
+
+	if !e.started {
+		e.remaining = e.ocvSoC(voltage) * capacity
+		e.restSince = now
+	} else {
+		delta := charge.MilliAmpHours
+		if (accReset == e.lastAccReset) && (accCount >= e.lastAccCount) {
+			delta -= e.lastCharge
+		}
+		e.remaining -= delta
+		e.removed += delta
+	}
+	e.started = true
+	e.lastCharge = charge.MilliAmpHours
+	e.lastAccCount = accCount
+	e.lastAccReset = accReset
+
+	if math.Abs(current) >= e.config.RestCurrent {
+		e.restSince = now
+	}
+
+	switch {
+	case (voltage >= e.config.FullVoltage) && (math.Abs(current) < e.config.TaperCurrent):
+		e.remaining = capacity
+		e.fullSeen = true
+		e.removed = 0
+		status.Full = true
+	case voltage <= e.config.EmptyVoltage:
+		if e.fullSeen && (e.removed > 0) {
+			e.state.LearnedCapacity = e.removed
+			capacity = e.removed
+			status.Capacity = capacity
+		}
+		e.remaining = 0
+		e.fullSeen = false
+		status.Empty = true
+	case now.Sub(e.restSince) >= e.config.RestTime:
+		e.remaining = e.ocvSoC(voltage) * capacity
+		status.Resting = true
+	}
+
+	e.remaining = math.Max(0, math.Min(capacity, e.remaining))
+	status.Remaining = e.remaining
+	status.SoC = e.remaining / capacity
+	if current > 0 {
+		status.TimeToEmpty = time.Duration(e.remaining / current * float64(time.Hour))
+	} else if current < 0 {
+		status.TimeToFull = time.Duration((capacity - e.remaining) / -current * float64(time.Hour))
+	}
+	return status, nil
+}
+
+func (e *Estimator) capacity() float64 {
+	if e.state.LearnedCapacity > 0 {
+		return e.state.LearnedCapacity
+	}
+	return e.config.Capacity
+}
+
+// ocvSoC interpolates the state of charge for an open-circuit voltage.
+func (e *Estimator) ocvSoC(voltage float64) float64 {
+	ocv := e.config.OCV
+	if voltage <= ocv[0].Voltage {
+		return ocv[0].SoC
+	}
+	for i := 1; i < len(ocv); i++ {
+		if voltage <= ocv[i].Voltage {
+			a, b := ocv[i-1], ocv[i]
+			return a.SoC + (b.SoC-a.SoC)*(voltage-a.Voltage)/(b.Voltage-a.Voltage)
+		}
+	}
+	return ocv[len(ocv)-1].SoC
+}
+
+// LoadState reads a State from a JSON file. A missing file yields an empty State.
+func LoadState(path string) (State, error) {
+	var state State
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	err = json.Unmarshal(data, &state)
+	if err != nil {
+		return state, err
+	}
+	return state, nil
+}
+
+// SaveState writes a State to a JSON file.
+func SaveState(path string, state State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
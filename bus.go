@@ -0,0 +1,181 @@
+package pac194x5x
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/i2c"
+)
+
+const (
+	// GeneralCallAddress is the I2C general call address.
+	GeneralCallAddress = 0x00
+	// AlertResponseAddress is the SMBus Alert Response Address.
+	AlertResponseAddress = 0x0c
+)
+
+// Bus tracks the devices sharing an I2C bus.
+type Bus struct {
+	bus i2c.Bus
+
+	mu   sync.Mutex
+	devs map[uint16]*Dev
+}
+
+// NewBus creates a Bus for b.
+func NewBus(b i2c.Bus) *Bus {
+	return &Bus{
+		bus:  b,
+		devs: make(map[uint16]*Dev),
+	}
+}
+
+// NewI2C initializes a power monitor on the bus and registers it.
+func (bus *Bus) NewI2C(addr uint16, voltageRatio []float64, rSense []float64, opts ...Option) (*Dev, error) {
+	dev, err := NewI2C(bus.bus, addr, voltageRatio, rSense, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = bus.Add(dev)
+	if err != nil {
+		return nil, err
+	}
+	return dev, nil
+}
+
+// Add registers dev, which must have been initialized on the same I2C bus.
+func (bus *Bus) Add(dev *Dev) error {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	addr := dev.i2cDev.Addr
+	if dev.i2cDev.Bus != bus.bus {
+		return fmt.Errorf("device at address 0x%02x is on another bus: %s", addr, dev.i2cDev.Bus)
+	}
+	if _, ok := bus.devs[addr]; ok {
+		return fmt.Errorf("device already registered at address 0x%02x", addr)
+	}
+	bus.devs[addr] = dev
+	return nil
+}
+
+// Remove unregisters dev.
+func (bus *Bus) Remove(dev *Dev) {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	delete(bus.devs, dev.i2cDev.Addr)
+}
+
+// Devices returns the registered devices, ordered by address.
+func (bus *Bus) Devices() []*Dev {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	devs := make([]*Dev, 0, len(bus.devs))
+	for _, dev := range bus.devs {
+		devs = append(devs, dev)
+	}
+	slices.SortFunc(devs, func(a, b *Dev) int {
+		return cmp.Compare(a.i2cDev.Addr, b.i2cDev.Addr)
+	})
+	return devs
+}
+
+// RefreshAll sends a Refresh_G command as an I2C general call, so that every device on the bus latches its
+// readings at the same instant, and invalidates the caches of the registered devices.
+func (bus *Bus) RefreshAll(delay time.Duration) error {
+	devs := bus.Devices()
+	for _, dev := range devs {
+		_, unlock := dev.acquire()
+		defer unlock()
+		dev.invalidate()
+	}
+
+	err := bus.bus.Tx(GeneralCallAddress, []byte{RefreshGRegister.Address}, nil)
+	if err != nil {
+		return newRegisterError(RefreshGRegister.Address, "write", err)
+	}
+	for _, dev := range devs {
+		dev.configChanged = false
+		dev.resetAccumulatorState()
+	}
+	time.Sleep(delay)
+	return nil
+}
+
+// AlertResponse issues an SMBus Alert Response Address read and returns the address of the device that is
+// asserting the shared ALERT line. An error is returned if no device responds.
+func (bus *Bus) AlertResponse() (uint16, error) {
+	r := make([]byte, 1)
+	err := bus.bus.Tx(AlertResponseAddress, nil, r)
+	if err != nil {
+		return 0, err
+	}
+	return uint16(r[0] >> 1), nil
+}
+
+// HandleAlert finds the registered device asserting the shared ALERT line and returns its decoded alert status.
+func (bus *Bus) HandleAlert() (*Dev, []AlertEvent, error) {
+	addr, err := bus.AlertResponse()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dev, err := bus.lookup(addr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dev, dev.readAlertEvents(), nil
+}
+
+// WatchAlerts arms falling edge detection on pin, which must be wired to the shared ALERT line. On every edge
+// each device asserting the line is identified using the Alert Response Address and its decoded alert status
+// is delivered. The returned channel is closed when ctx is cancelled.
+func (bus *Bus) WatchAlerts(ctx context.Context, pin gpio.PinIn) (<-chan AlertEvent, error) {
+	err := pin.In(gpio.PullUp, gpio.FallingEdge)
+	if err != nil {
+		return nil, err
+	}
+
+	return watchAlertPin(ctx, pin, bus.readAlertEvents), nil
+}
+
+// readAlertEvents handles alerts until no device responds to the Alert Response Address. Each device releases
+// the line once its alert status has been read, so there is at most one round per registered device.
+func (bus *Bus) readAlertEvents() []AlertEvent {
+	var events []AlertEvent
+	for range len(bus.Devices()) {
+		t := time.Now()
+		addr, err := bus.AlertResponse()
+		if err != nil {
+			break
+		}
+
+		dev, err := bus.lookup(addr)
+		if err != nil {
+			events = append(events, AlertEvent{Time: t, Addr: addr, Channel: -1, Err: err})
+			break
+		}
+
+		events = append(events, dev.readAlertEvents()...)
+	}
+	return events
+}
+
+func (bus *Bus) lookup(addr uint16) (*Dev, error) {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	dev, ok := bus.devs[addr]
+	if !ok {
+		return nil, fmt.Errorf("no device registered at address 0x%02x", addr)
+	}
+	return dev, nil
+}
@@ -0,0 +1,42 @@
+package pac194x5x
+
+import (
+	"testing"
+)
+
+func TestBusAdd(t *testing.T) {
+	b := newFakeBus(PAC1942_1)
+	bus := NewBus(b)
+	dev, err := bus.NewI2C(0x10, []float64{1, 1}, []float64{0.01, 0.01})
+	if err != nil {
+		t.Fatalf("NewI2C() error = %v", err)
+	}
+	err = bus.Add(dev)
+	if err == nil {
+		t.Error("Add() of a registered address succeeded")
+	}
+
+	other, err := NewI2C(newFakeBus(PAC1942_1), 0x11, []float64{1, 1}, []float64{0.01, 0.01})
+	if err != nil {
+		t.Fatalf("NewI2C() error = %v", err)
+	}
+	err = bus.Add(other)
+	if err == nil {
+		t.Error("Add() of a device on another bus succeeded")
+	}
+	if got := len(bus.Devices()); got != 1 {
+		t.Errorf("Devices() = %d devices, want 1", got)
+	}
+
+	accReset := other.GetAccReset()
+	err = bus.RefreshAll(0)
+	if err != nil {
+		t.Fatalf("RefreshAll() error = %v", err)
+	}
+	if got := other.GetAccReset(); got != accReset {
+		t.Errorf("GetAccReset() of a device on another bus = %d, want %d", got, accReset)
+	}
+	if got := b.writeCount(RefreshGRegister.Address); got != 1 {
+		t.Errorf("REFRESH_G writes = %d, want 1", got)
+	}
+}
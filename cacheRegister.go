@@ -1,5 +1,207 @@
 package pac194x5x
 
+import (
+	"sync"
+)
+
+// registerCache holds the cached registers of a device.
+type registerCache struct {
+	accCount       *CacheRegister[uint32]
+	ctrl           *CacheRegister[uint16]
+	vAcc           [4]*CacheRegister[uint64]
+	vBus           [4]*CacheRegister[uint16]
+	vSense         [4]*CacheRegister[uint16]
+	vBusAvg        [4]*CacheRegister[uint16]
+	vSenseAvg      [4]*CacheRegister[uint16]
+	vPower         [4]*CacheRegister[uint32]
+	smBus          *CacheRegister[uint8]
+	negPwrFsr      *CacheRegister[uint16]
+	ctrlAct        *CacheRegister[uint16]
+	negPwrFsrAct   *CacheRegister[uint16]
+	ctrlLat        *CacheRegister[uint16]
+	negPwrFsrLat   *CacheRegister[uint16]
+	accumConfig    *CacheRegister[uint8]
+	accumConfigAct *CacheRegister[uint8]
+	accumConfigLat *CacheRegister[uint8]
+	alertStatus    *CacheRegister[Alerts]
+	alertEnable    *CacheRegister[Alerts]
+	productID      *CacheRegister[ProductID]
+	manufacturerID *CacheRegister[uint8]
+	revisionID     *CacheRegister[uint8]
+
+	all []Cached
+}
+
+func newRegisterCache() *registerCache {
+	rc := &registerCache{
+		accCount: NewCacheRegister[uint32](AccCountRegister, true),
+		ctrl:     NewCacheRegister[uint16](CtrlRegister, true),
+		vAcc: [4]*CacheRegister[uint64]{
+			NewCacheRegister[uint64](VAcc1Register, true),
+			NewCacheRegister[uint64](VAcc2Register, true),
+			NewCacheRegister[uint64](VAcc3Register, true),
+			NewCacheRegister[uint64](VAcc4Register, true),
+		},
+		vBus: [4]*CacheRegister[uint16]{
+			NewCacheRegister[uint16](VBus1Register, true),
+			NewCacheRegister[uint16](VBus2Register, true),
+			NewCacheRegister[uint16](VBus3Register, true),
+			NewCacheRegister[uint16](VBus4Register, true),
+		},
+		vSense: [4]*CacheRegister[uint16]{
+			NewCacheRegister[uint16](VSense1Register, true),
+			NewCacheRegister[uint16](VSense2Register, true),
+			NewCacheRegister[uint16](VSense3Register, true),
+			NewCacheRegister[uint16](VSense4Register, true),
+		},
+		vBusAvg: [4]*CacheRegister[uint16]{
+			NewCacheRegister[uint16](VBus1AvgRegister, true),
+			NewCacheRegister[uint16](VBus2AvgRegister, true),
+			NewCacheRegister[uint16](VBus3AvgRegister, true),
+			NewCacheRegister[uint16](VBus4AvgRegister, true),
+		},
+		vSenseAvg: [4]*CacheRegister[uint16]{
+			NewCacheRegister[uint16](VSense1AvgRegister, true),
+			NewCacheRegister[uint16](VSense2AvgRegister, true),
+			NewCacheRegister[uint16](VSense3AvgRegister, true),
+			NewCacheRegister[uint16](VSense4AvgRegister, true),
+		},
+		vPower: [4]*CacheRegister[uint32]{
+			NewCacheRegister[uint32](VPower1Register, true),
+			NewCacheRegister[uint32](VPower2Register, true),
+			NewCacheRegister[uint32](VPower3Register, true),
+			NewCacheRegister[uint32](VPower4Register, true),
+		},
+		smBus:          NewCacheRegister[uint8](SMBusRegister, false),
+		negPwrFsr:      NewCacheRegister[uint16](NegPwrFsrRegister, true),
+		ctrlAct:        NewCacheRegister[uint16](CtrlActRegister, true),
+		negPwrFsrAct:   NewCacheRegister[uint16](NegPwrFsrActRegister, true),
+		ctrlLat:        NewCacheRegister[uint16](CtrlLatRegister, true),
+		negPwrFsrLat:   NewCacheRegister[uint16](NegPwrFsrLatRegister, true),
+		accumConfig:    NewCacheRegister[uint8](AccumConfigRegister, true),
+		accumConfigAct: NewCacheRegister[uint8](AccumConfigActRegister, true),
+		accumConfigLat: NewCacheRegister[uint8](AccumConfigLatRegister, true),
+		alertStatus:    NewCacheRegister[Alerts](AlertStatusRegister, false),
+		alertEnable:    NewCacheRegister[Alerts](AlertEnableRegister, true),
+		productID:      NewCacheRegister[ProductID](ProductIDRegister, true),
+		manufacturerID: NewCacheRegister[uint8](ManufacturerIDRegister, true),
+		revisionID:     NewCacheRegister[uint8](RevisionIDRegister, true),
+	}
+
+	rc.all = []Cached{rc.ctrl, rc.accCount}
+	for _, cr := range rc.vAcc {
+		rc.all = append(rc.all, cr)
+	}
+	for _, crs := range [][4]*CacheRegister[uint16]{rc.vBus, rc.vSense, rc.vBusAvg, rc.vSenseAvg} {
+		for _, cr := range crs {
+			rc.all = append(rc.all, cr)
+		}
+	}
+	for _, cr := range rc.vPower {
+		rc.all = append(rc.all, cr)
+	}
+	rc.all = append(rc.all,
+		rc.smBus,
+		rc.negPwrFsr,
+		rc.ctrlAct,
+		rc.negPwrFsrAct,
+		rc.ctrlLat,
+		rc.negPwrFsrLat,
+		rc.accumConfig,
+		rc.accumConfigAct,
+		rc.accumConfigLat,
+		rc.alertStatus,
+		rc.alertEnable,
+		rc.productID,
+		rc.manufacturerID,
+		rc.revisionID,
+	)
+
+	return rc
+}
+
+// invalidate invalidates all cached registers.
+func (rc *registerCache) invalidate() {
+	for _, cached := range rc.all {
+		cached.Invalidate()
+	}
+}
+
+type Cached interface {
+	IsValid() bool
+	Invalidate()
+}
+
+type RegisterReader interface {
+	ReadRegister(address uint8, len int) ([]byte, error)
+}
+
+type RegisterWriter interface {
+	WriteRegister(address uint8, data []byte) error
+}
+
+type CacheRegister[T any] struct {
+	register       Register[T]
+	cachedRegister bool
+	value          T
+	valid          bool
+}
+
+func NewCacheRegister[T any](register Register[T], cachedRegister bool) *CacheRegister[T] {
+	return &CacheRegister[T]{
+		register:       register,
+		cachedRegister: cachedRegister,
+	}
+}
+
+func (cr *CacheRegister[T]) IsValid() bool {
+	return cr.valid
+}
+
+func (cr *CacheRegister[T]) Invalidate() {
+	cr.valid = false
+}
+
+func (cr *CacheRegister[T]) Read(reader RegisterReader) (T, error) {
+	if cr.valid {
+		return cr.value, nil
+	}
+	data, err := reader.ReadRegister(cr.register.Address, cr.register.Length)
+	if err != nil {
+		return *new(T), err
+	}
+	v, err := cr.register.Codec.Unmarshal(data)
+	if err != nil {
+		return *new(T), newRegisterError(cr.register.Address, "read", err)
+	}
+	if cr.cachedRegister {
+		cr.valid = true
+		cr.value = v
+	}
+	return v, nil
+}
+
+func (cr *CacheRegister[T]) Write(writer RegisterWriter, v T) error {
+	data, err := cr.register.Codec.Marshal(v)
+	if err != nil {
+		return newRegisterError(cr.register.Address, "write", err)
+	}
+	err = writer.WriteRegister(cr.register.Address, data)
+	if err != nil {
+		return err
+	}
+	if cr.cachedRegister {
+		cr.valid = true
+		cr.value = v
+	}
+	return nil
+}
+
+// Package-level cache registers, kept for compatibility.
+//
+// Deprecated: the package-level cache registers are shared by all devices, so their cached values may belong to
+// another device. They are invalidated whenever any device is refreshed. Dev keeps its own per-device cache; use
+// the Dev getters and setters instead.
 var (
 	AccCountCacheRegister       = NewCacheRegister[uint32](AccCountRegister, true)
 	CtrlCacheRegister           = NewCacheRegister[uint16](CtrlRegister, true)
@@ -40,7 +242,8 @@ var (
 	ManufacturerIDCacheRegister = NewCacheRegister[uint8](ManufacturerIDRegister, true)
 	RevisionIDCacheRegister     = NewCacheRegister[uint8](RevisionIDRegister, true)
 
-	cacheRegisters = []Cached{
+	cacheRegistersMu sync.Mutex
+	cacheRegisters   = []Cached{
 		CtrlCacheRegister,
 		AccCountCacheRegister,
 		VAcc1CacheRegister,
@@ -82,72 +285,12 @@ var (
 	}
 )
 
-type Cached interface {
-	IsValid() bool
-	Invalidate()
-}
-
-type RegisterReader interface {
-	ReadRegister(address uint8, len int) ([]byte, error)
-}
-
-type RegisterWriter interface {
-	WriteRegister(address uint8, data []byte) error
-}
-
-type CacheRegister[T any] struct {
-	register       Register[T]
-	cachedRegister bool
-	value          T
-	valid          bool
-}
+// invalidateCacheRegisters invalidates the package-level cache registers.
+func invalidateCacheRegisters() {
+	cacheRegistersMu.Lock()
+	defer cacheRegistersMu.Unlock()
 
-func NewCacheRegister[T any](register Register[T], cachedRegister bool) *CacheRegister[T] {
-	return &CacheRegister[T]{
-		register:       register,
-		cachedRegister: cachedRegister,
+	for _, cached := range cacheRegisters {
+		cached.Invalidate()
 	}
 }
-
-func (cr *CacheRegister[T]) IsValid() bool {
-	return cr.valid
-}
-
-func (cr *CacheRegister[T]) Invalidate() {
-	cr.valid = false
-}
-
-func (cr *CacheRegister[T]) Read(reader RegisterReader) (T, error) {
-	if cr.valid {
-		return cr.value, nil
-	}
-	data, err := reader.ReadRegister(cr.register.Address, cr.register.Length)
-	if err != nil {
-		return *new(T), err
-	}
-	v, err := cr.register.Codec.Unmarshal(data)
-	if err != nil {
-		return *new(T), err
-	}
-	if cr.cachedRegister {
-		cr.valid = true
-		cr.value = v
-	}
-	return v, nil
-}
-
-func (cr *CacheRegister[T]) Write(writer RegisterWriter, v T) error {
-	data, err := cr.register.Codec.Marshal(v)
-	if err != nil {
-		return err
-	}
-	err = writer.WriteRegister(cr.register.Address, data)
-	if err != nil {
-		return err
-	}
-	if cr.cachedRegister {
-		cr.valid = true
-		cr.value = v
-	}
-	return nil
-}
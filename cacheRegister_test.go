@@ -0,0 +1,32 @@
+package pac194x5x
+
+import (
+	"testing"
+)
+
+func TestPackageCacheRegistersInvalidated(t *testing.T) {
+	bus := newFakeBus(PAC1942_1)
+	dev, err := NewI2C(bus, 0x10, []float64{1, 1}, []float64{0.01, 0.01})
+	if err != nil {
+		t.Fatalf("NewI2C() error = %v", err)
+	}
+
+	bus.setRegister(VBus1Register.Address, 0x12, 0x34)
+	v, err := VBus1CacheRegister.Read(dev)
+	if (err != nil) || (v != 0x1234) {
+		t.Fatalf("VBus1CacheRegister.Read() = %#x, %v, want 0x1234", v, err)
+	}
+
+	bus.setRegister(VBus1Register.Address, 0x56, 0x78)
+	err = dev.Refresh(0)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if VBus1CacheRegister.IsValid() {
+		t.Error("VBus1CacheRegister valid after Refresh")
+	}
+	v, err = VBus1CacheRegister.Read(dev)
+	if (err != nil) || (v != 0x5678) {
+		t.Errorf("VBus1CacheRegister.Read() = %#x, %v, want 0x5678", v, err)
+	}
+}
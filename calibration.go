@@ -0,0 +1,212 @@
+package pac194x5x
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+)
+
+// Correction is a linear correction applied to a nominal value: corrected = nominal * Gain + Offset.
+type Correction struct {
+	Gain   float64 `json:"gain"`   // Gain factor.
+	Offset float64 `json:"offset"` // Offset in the unit of the corrected value.
+}
+
+// IdentityCorrection leaves values unchanged.
+var IdentityCorrection = Correction{Gain: 1}
+
+// Apply corrects a nominal value.
+func (c Correction) Apply(v float64) float64 {
+	return v*c.Gain + c.Offset
+}
+
+// Invert converts a corrected value back to its nominal value, e.g. when programming limits.
+func (c Correction) Invert(v float64) float64 {
+	return (v - c.Offset) / c.Gain
+}
+
+func (c Correction) validate() error {
+	if (c.Gain == 0) || math.IsNaN(c.Gain) || math.IsInf(c.Gain, 0) || math.IsNaN(c.Offset) || math.IsInf(c.Offset, 0) {
+		return fmt.Errorf("invalid correction: gain %f, offset %f", c.Gain, c.Offset)
+	}
+	return nil
+}
+
+// TwoPoint computes the correction that maps two nominal readings to their reference values. The readings must
+// be taken with IdentityCorrection in effect.
+func TwoPoint(measured1 float64, reference1 float64, measured2 float64, reference2 float64) (Correction, error) {
+	if measured1 == measured2 {
+		return Correction{}, fmt.Errorf("calibration points must differ")
+	}
+	gain := (reference2 - reference1) / (measured2 - measured1)
+	c := Correction{
+		Gain:   gain,
+		Offset: reference1 - gain*measured1,
+	}
+	return c, c.validate()
+}
+
+// ChannelCalibration holds the corrections of a channel.
+type ChannelCalibration struct {
+	Voltage Correction `json:"voltage"` // Voltage correction of VBUS in V.
+	Current Correction `json:"current"` // Current correction in mA.
+}
+
+// DefaultChannelCalibration leaves all values unchanged.
+var DefaultChannelCalibration = ChannelCalibration{
+	Voltage: IdentityCorrection,
+	Current: IdentityCorrection,
+}
+
+// Calibration holds the corrections of all channels of a device.
+type Calibration struct {
+	Channels []ChannelCalibration `json:"channels"` // Channels holds the per-channel corrections.
+}
+
+// LoadCalibration reads a Calibration from a JSON file.
+func LoadCalibration(path string) (Calibration, error) {
+	var calibration Calibration
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return calibration, err
+	}
+	err = json.Unmarshal(data, &calibration)
+	if err != nil {
+		return calibration, err
+	}
+	return calibration, nil
+}
+
+// SaveCalibration writes a Calibration to a JSON file.
+func SaveCalibration(path string, calibration Calibration) error {
+	data, err := json.MarshalIndent(calibration, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// GetCalibration returns the calibration of all channels.
+func (dev *Dev) GetCalibration() Calibration {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	return Calibration{
+		Channels: append([]ChannelCalibration(nil), dev.calibration...),
+	}
+}
+
+// SetCalibration sets the calibration of all channels. Channels missing from calibration are reset to
+// DefaultChannelCalibration.
+func (dev *Dev) SetCalibration(calibration Calibration) error {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	if len(calibration.Channels) > dev.channelCount {
+		return fmt.Errorf("%w: calibration for %d channels, device has %d", ErrInvalidChannel, len(calibration.Channels), dev.channelCount)
+	}
+	channels := make([]ChannelCalibration, dev.channelCount)
+	for channelNo := range channels {
+		channels[channelNo] = DefaultChannelCalibration
+		if channelNo < len(calibration.Channels) {
+			err := calibration.Channels[channelNo].validate()
+			if err != nil {
+				return err
+			}
+			channels[channelNo] = calibration.Channels[channelNo]
+		}
+	}
+	dev.calibration = channels
+	return nil
+}
+
+// SetChannelCalibration sets the calibration of a channel.
+func (dev *Dev) SetChannelCalibration(channelNo int, calibration ChannelCalibration) error {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.checkChannelNo(channelNo)
+	if err != nil {
+		return err
+	}
+	err = calibration.validate()
+	if err != nil {
+		return err
+	}
+	dev.calibration[channelNo] = calibration
+	return nil
+}
+
+func (cc ChannelCalibration) validate() error {
+	err := cc.Voltage.validate()
+	if err != nil {
+		return fmt.Errorf("voltage: %w", err)
+	}
+	err = cc.Current.validate()
+	if err != nil {
+		return fmt.Errorf("current: %w", err)
+	}
+	return nil
+}
+
+// calibrateVSense corrects a nominal VSENSE value in mV using the current correction.
+func (dev *Dev) calibrateVSense(channelNo int, vSense float64) (float64, error) {
+	c := dev.calibration[channelNo].Current
+	if c.Offset == 0 {
+		return vSense * c.Gain, nil
+	}
+	rSense, err := dev.getRSense(channelNo)
+	if err != nil {
+		return 0, err
+	}
+	return vSense*c.Gain + c.Offset*rSense, nil
+}
+
+// calibratePower corrects a nominal power in W given the corrected voltage in V and current in mA it was
+// computed from.
+func (dev *Dev) calibratePower(channelNo int, power float64, vBus float64, current float64) float64 {
+	cc := dev.calibration[channelNo]
+	v := cc.Voltage.Invert(vBus)
+	i := cc.Current.Invert(current)
+	// (v * gv + ov) * (i * gi + oi) with v * i taken from the power register.
+	crossTerms := v*cc.Voltage.Gain*cc.Current.Offset + i*cc.Current.Gain*cc.Voltage.Offset + cc.Voltage.Offset*cc.Current.Offset
+	return power*cc.Voltage.Gain*cc.Current.Gain + crossTerms/1000
+}
+
+// calibrateAccumulated corrects a nominal sum of count samples accumulated in mode. The offset terms of an
+// accumulated power use the rolling average VBUS and current.
+func (dev *Dev) calibrateAccumulated(channelNo int, mode AccumMode, sum float64, count uint32) (float64, error) {
+	cc := dev.calibration[channelNo]
+	switch mode {
+	case AccumModeVPower:
+		if cc == DefaultChannelCalibration {
+			return sum, nil
+		}
+		vBusAvg, err := dev.GetVBusAvg(channelNo)
+		if err != nil {
+			return 0, err
+		}
+		currentAvg, err := dev.GetCurrentAvg(channelNo)
+		if err != nil {
+			return 0, err
+		}
+		// Sum of (v * gv + ov) * (i * gi + oi) over count samples, with the sums of v and i estimated from the
+		// averages.
+		n := float64(count)
+		v := cc.Voltage.Invert(vBusAvg) * n
+		i := cc.Current.Invert(currentAvg) * n
+		crossTerms := v*cc.Voltage.Gain*cc.Current.Offset + i*cc.Current.Gain*cc.Voltage.Offset + n*cc.Voltage.Offset*cc.Current.Offset
+		return sum*cc.Voltage.Gain*cc.Current.Gain + crossTerms/1000, nil
+	case AccumModeVSense:
+		rSense, err := dev.getRSense(channelNo)
+		if err != nil {
+			return 0, err
+		}
+		return sum*cc.Current.Gain + cc.Current.Offset*rSense*float64(count), nil
+	case AccumModeVBus:
+		return sum*cc.Voltage.Gain + cc.Voltage.Offset*float64(count), nil
+	default:
+		return sum, nil
+	}
+}
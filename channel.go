@@ -0,0 +1,155 @@
+package pac194x5x
+
+import (
+	"fmt"
+	"math"
+)
+
+// GetVoltageRatio returns the voltage divider ratio of a channel.
+func (dev *Dev) GetVoltageRatio(channelNo int) (float64, error) {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.checkChannelNo(channelNo)
+	if err != nil {
+		return 0, err
+	}
+	return dev.voltageRatio[channelNo], nil
+}
+
+// SetVoltageRatio sets the voltage divider ratio of a channel.
+func (dev *Dev) SetVoltageRatio(channelNo int, voltageRatio float64) error {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.checkChannelNo(channelNo)
+	if err != nil {
+		return err
+	}
+	err = checkParameter("voltageRatio", channelNo, voltageRatio)
+	if err != nil {
+		return err
+	}
+	dev.voltageRatio[channelNo] = voltageRatio
+	return nil
+}
+
+// GetRSense returns the sense resistor value of a channel in Ω. If a shunt model is set, its compensated
+// resistance is returned.
+func (dev *Dev) GetRSense(channelNo int) (float64, error) {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.checkChannelNo(channelNo)
+	if err != nil {
+		return 0, err
+	}
+	return dev.getRSense(channelNo)
+}
+
+// SetRSense sets the sense resistor value of a channel in Ω. Any shunt model of the channel is removed.
+func (dev *Dev) SetRSense(channelNo int, rSense float64) error {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.checkChannelNo(channelNo)
+	if err != nil {
+		return err
+	}
+	err = checkParameter("rSense", channelNo, rSense)
+	if err != nil {
+		return err
+	}
+	dev.rSense[channelNo] = rSense
+	delete(dev.shunts, channelNo)
+	return nil
+}
+
+// checkParameters validates and copies the per-channel parameters supplied to NewI2C.
+func checkParameters(name string, values []float64, channelCount int) ([]float64, error) {
+	if len(values) < channelCount {
+		return nil, &ParameterError{Parameter: name, Channel: len(values), Err: ErrMissingParameter}
+	}
+	for channelNo, v := range values[:channelCount] {
+		err := checkParameter(name, channelNo, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return append([]float64(nil), values[:channelCount]...), nil
+}
+
+func checkParameter(name string, channelNo int, v float64) error {
+	if (v <= 0) || math.IsNaN(v) || math.IsInf(v, 0) {
+		return &ParameterError{Parameter: name, Channel: channelNo, Value: v, Err: ErrInvalidParameter}
+	}
+	return nil
+}
+
+// EnableChannel turns a channel on. The change takes effect after the next refresh.
+func (dev *Dev) EnableChannel(channelNo int) error {
+	return dev.setChannelOff(channelNo, false)
+}
+
+// DisableChannel turns a channel off, which raises the sample rate of the remaining channels in fast and burst
+// modes. The change takes effect after the next refresh.
+func (dev *Dev) DisableChannel(channelNo int) error {
+	return dev.setChannelOff(channelNo, true)
+}
+
+// ActiveChannels returns the channels that are on according to the Ctrl_Lat register.
+func (dev *Dev) ActiveChannels() ([]int, error) {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	ctrlLat, err := dev.GetCtrlLat()
+	if err != nil {
+		return nil, err
+	}
+
+	var activeChannels []int
+	for channelNo := range dev.channelCount {
+		if GetField(ctrlLat, ChannelOffFields[channelNo]) == 0 {
+			activeChannels = append(activeChannels, channelNo)
+		}
+	}
+	return activeChannels, nil
+}
+
+func (dev *Dev) setChannelOff(channelNo int, off bool) error {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.checkChannelNo(channelNo)
+	if err != nil {
+		return err
+	}
+
+	ctrl, err := dev.GetCtrl()
+	if err != nil {
+		return err
+	}
+	var bitValue uint16
+	if off {
+		bitValue = 1
+	}
+	return dev.SetCtrl(SetField(ctrl, ChannelOffFields[channelNo], bitValue))
+}
+
+// checkChannelActive checks that channelNo is valid and that the channel is on according to the Ctrl_Lat
+// register.
+func (dev *Dev) checkChannelActive(channelNo int) error {
+	err := dev.checkChannelNo(channelNo)
+	if err != nil {
+		return err
+	}
+
+	ctrlLat, err := dev.GetCtrlLat()
+	if err != nil {
+		return err
+	}
+	if GetField(ctrlLat, ChannelOffFields[channelNo]) != 0 {
+		return fmt.Errorf("%w: %d", ErrChannelDisabled, channelNo)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package pac194x5x
 import (
 	"encoding/binary"
 	"fmt"
+	"strings"
 )
 
 // Codec is an interface for marshalling/unmarshalling data.
@@ -12,14 +13,25 @@ type Codec[T any] interface {
 }
 
 var (
-	VoidCodec      = &voidCodec{}   // VoidCodec - Codec for Void.
-	Uint8Codec     = &uint8Codec{}  // Uint8Codec - Codec for uint8.
-	Uint16Codec    = &uint16Codec{} // Uint16Codec - Codec for uint16.
-	Uint32Codec    = &uint32Codec{} // Uint32Codec - Codec for uint32.
-	Uint64Codec    = &uint64Codec{}
+	VoidCodec      = &voidCodec{}      // VoidCodec - Codec for Void.
+	Uint8Codec     = &uint8Codec{}     // Uint8Codec - Codec for uint8.
+	Uint16Codec    = &uint16Codec{}    // Uint16Codec - Codec for uint16.
+	Uint32Codec    = &uint32Codec{}    // Uint32Codec - Codec for uint32.
+	Uint64Codec    = &uint64Codec{}    // Uint64Codec - Codec for 56-bit uint64.
 	ProductIDCodec = &productIDCodec{} // ProductIDCodec - Codec for ProductID.
+	AlertsCodec    = &alertsCodec{}    // AlertsCodec - Codec for Alerts.
+
+	Uint24Codec = NewBigEndianCodec[uint32](3) // Uint24Codec - Codec for 24-bit uint32.
+	Int16Codec  = NewBigEndianCodec[int16](2)  // Int16Codec - Codec for two's complement int16.
+	Int32Codec  = NewBigEndianCodec[int32](4)  // Int32Codec - Codec for two's complement int32.
+	Int56Codec  = NewBigEndianCodec[int64](7)  // Int56Codec - Codec for two's complement 56-bit int64.
 )
 
+// Integer is the set of types supported by NewBigEndianCodec.
+type Integer interface {
+	~int8 | ~int16 | ~int32 | ~int64 | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
 type Void any
 
 type voidCodec struct {
@@ -31,7 +43,7 @@ func (codec *voidCodec) Marshal(_ Void) ([]byte, error) {
 
 func (codec *voidCodec) Unmarshal(data []byte) (Void, error) {
 	if len(data) != 0 {
-		return 0, fmt.Errorf("expected 0 bytes, got %d", len(data))
+		return 0, fmt.Errorf("%w: expected 0 bytes, got %d", ErrCodecLength, len(data))
 	}
 	return nil, nil
 }
@@ -45,7 +57,7 @@ func (codec *uint8Codec) Marshal(value uint8) ([]byte, error) {
 
 func (codec *uint8Codec) Unmarshal(data []byte) (uint8, error) {
 	if len(data) != 1 {
-		return 0, fmt.Errorf("expected 1 byte, got %d", len(data))
+		return 0, fmt.Errorf("%w: expected 1 byte, got %d", ErrCodecLength, len(data))
 	}
 	return data[0], nil
 }
@@ -59,7 +71,7 @@ func (codec *uint16Codec) Marshal(value uint16) ([]byte, error) {
 
 func (codec *uint16Codec) Unmarshal(data []byte) (uint16, error) {
 	if len(data) != 2 {
-		return 0, fmt.Errorf("expected 2 bytes, got %d", len(data))
+		return 0, fmt.Errorf("%w: expected 2 bytes, got %d", ErrCodecLength, len(data))
 	}
 	return binary.BigEndian.Uint16(data), nil
 }
@@ -73,7 +85,7 @@ func (codec *uint32Codec) Marshal(value uint32) ([]byte, error) {
 
 func (codec *uint32Codec) Unmarshal(data []byte) (uint32, error) {
 	if len(data) != 4 {
-		return 0, fmt.Errorf("expected 4 bytes, got %d", len(data))
+		return 0, fmt.Errorf("%w: expected 4 bytes, got %d", ErrCodecLength, len(data))
 	}
 	return binary.BigEndian.Uint32(data), nil
 }
@@ -87,7 +99,7 @@ func (codec *uint64Codec) Marshal(value uint64) ([]byte, error) {
 
 func (codec *uint64Codec) Unmarshal(data []byte) (uint64, error) {
 	if len(data) != 7 {
-		return 0, fmt.Errorf("expected 7 bytes, got %d", len(data))
+		return 0, fmt.Errorf("%w: expected 7 bytes, got %d", ErrCodecLength, len(data))
 	}
 	adjustedData := append([]byte{0x00}, data...)
 	return binary.BigEndian.Uint64(adjustedData), nil
@@ -107,3 +119,225 @@ func (codec *productIDCodec) Unmarshal(data []byte) (ProductID, error) {
 	}
 	return ProductID(v), nil
 }
+
+type alertsCodec struct {
+}
+
+func (codec *alertsCodec) Marshal(value Alerts) ([]byte, error) {
+	return Uint24Codec.Marshal(uint32(value))
+}
+
+func (codec *alertsCodec) Unmarshal(data []byte) (Alerts, error) {
+	v, err := Uint24Codec.Unmarshal(data)
+	if err != nil {
+		return 0, err
+	}
+	return Alerts(v), nil
+}
+
+type bigEndianCodec[T Integer] struct {
+	length int
+	signed bool
+}
+
+// NewBigEndianCodec creates a Codec for a big-endian integer of length bytes. Signed types are decoded as two's
+// complement and sign-extended. length must be between 1 and the size of T.
+func NewBigEndianCodec[T Integer](length int) Codec[T] {
+	if (length < 1) || (length > binary.Size(T(0))) {
+		panic(fmt.Sprintf("invalid codec length: %d", length))
+	}
+	var zero T
+	return &bigEndianCodec[T]{
+		length: length,
+		signed: zero-1 < zero,
+	}
+}
+
+func (codec *bigEndianCodec[T]) Marshal(value T) ([]byte, error) {
+	bits := uint(codec.length * 8)
+	if bits < 64 {
+		if codec.signed {
+			limit := int64(1) << (bits - 1)
+			if (int64(value) < -limit) || (int64(value) >= limit) {
+				return nil, fmt.Errorf("%w: %d does not fit in %d bytes", ErrCodecRange, value, codec.length)
+			}
+		} else if uint64(value) >= uint64(1)<<bits {
+			return nil, fmt.Errorf("%w: %d does not fit in %d bytes", ErrCodecRange, value, codec.length)
+		}
+	}
+	return binary.BigEndian.AppendUint64(nil, uint64(value))[8-codec.length:], nil
+}
+
+func (codec *bigEndianCodec[T]) Unmarshal(data []byte) (T, error) {
+	if len(data) != codec.length {
+		return 0, fmt.Errorf("%w: expected %d bytes, got %d", ErrCodecLength, codec.length, len(data))
+	}
+	var v uint64
+	for _, b := range data {
+		v = (v << 8) | uint64(b)
+	}
+	if codec.signed {
+		shift := uint(64 - codec.length*8)
+		return T(int64(v<<shift) >> shift), nil
+	}
+	return T(v), nil
+}
+
+// decodeSigned decodes code as two's complement using signed if bidir is true, and as unsigned otherwise.
+func decodeSigned[U, S Integer](code U, bidir bool, unsigned Codec[U], signed Codec[S]) (int64, error) {
+	if !bidir {
+		return int64(code), nil
+	}
+	data, err := unsigned.Marshal(code)
+	if err != nil {
+		return 0, err
+	}
+	v, err := signed.Unmarshal(data)
+	if err != nil {
+		return 0, err
+	}
+	return int64(v), nil
+}
+
+// Field describes a bitfield of a register.
+type Field struct {
+	Name     string // Datasheet name of field.
+	Position int    // Position of the least significant bit.
+	Width    int    // Width in bits.
+}
+
+func (f Field) mask() uint64 {
+	return (uint64(1)<<f.Width - 1) << f.Position
+}
+
+// GetField returns the value of field f of register value v.
+func GetField[T Integer](v T, f Field) T {
+	return T((uint64(v) & f.mask()) >> f.Position)
+}
+
+// SetField returns register value v with field f set to fieldValue. Bits of fieldValue beyond the width of f are
+// discarded.
+func SetField[T Integer](v T, f Field, fieldValue T) T {
+	return T((uint64(v) &^ f.mask()) | ((uint64(fieldValue) << f.Position) & f.mask()))
+}
+
+// FieldValue holds the value of a field.
+type FieldValue struct {
+	Field
+	Value uint64 // Value of field.
+}
+
+// Layout describes the bitfields of a register. Bits not covered by a field are reserved.
+type Layout struct {
+	Length int     // Length in bytes.
+	Fields []Field // Fields, from the most significant.
+}
+
+// NewLayout creates a Layout for a register of length bytes. It panics if a field is outside the register or
+// overlaps another field.
+func NewLayout(length int, fields ...Field) Layout {
+	var used uint64
+	for _, f := range fields {
+		if (f.Width < 1) || (f.Position < 0) || (f.Position+f.Width > length*8) {
+			panic(fmt.Sprintf("field %s outside register", f.Name))
+		}
+		if used&f.mask() != 0 {
+			panic(fmt.Sprintf("field %s overlaps another field", f.Name))
+		}
+		used |= f.mask()
+	}
+	return Layout{Length: length, Fields: fields}
+}
+
+// Field returns the field named name.
+func (l Layout) Field(name string) (Field, bool) {
+	for _, f := range l.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// Get returns the value of the field named name of register value v.
+func (l Layout) Get(v uint64, name string) (uint64, error) {
+	f, ok := l.Field(name)
+	if !ok {
+		return 0, fmt.Errorf("unknown field: %s", name)
+	}
+	return GetField(v, f), nil
+}
+
+// Set returns register value v with the field named name set to fieldValue.
+func (l Layout) Set(v uint64, name string, fieldValue uint64) (uint64, error) {
+	f, ok := l.Field(name)
+	if !ok {
+		return 0, fmt.Errorf("unknown field: %s", name)
+	}
+	if fieldValue > f.mask()>>f.Position {
+		return 0, fmt.Errorf("%w: %d does not fit in field %s", ErrCodecRange, fieldValue, name)
+	}
+	return SetField(v, f, fieldValue), nil
+}
+
+// Decode returns the values of all fields of register value v.
+func (l Layout) Decode(v uint64) []FieldValue {
+	values := make([]FieldValue, len(l.Fields))
+	for i, f := range l.Fields {
+		values[i] = FieldValue{Field: f, Value: GetField(v, f)}
+	}
+	return values
+}
+
+// Format returns a human-readable decoding of register value v, e.g. "SAMPLE_MODE=0x0 CHANNEL_1_OFF=0x1".
+func (l Layout) Format(v uint64) string {
+	var sb strings.Builder
+	for i, fv := range l.Decode(v) {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		fmt.Fprintf(&sb, "%s=0x%x", fv.Name, fv.Value)
+	}
+	return sb.String()
+}
+
+// FieldValues maps field names to field values.
+type FieldValues map[string]uint64
+
+type bitfieldCodec struct {
+	layout Layout
+	codec  Codec[uint64]
+}
+
+// NewBitfieldCodec creates a Codec that marshals/unmarshals the fields of layout. Fields missing from the
+// marshalled FieldValues and reserved bits are zero.
+func NewBitfieldCodec(layout Layout) Codec[FieldValues] {
+	return &bitfieldCodec{
+		layout: layout,
+		codec:  NewBigEndianCodec[uint64](layout.Length),
+	}
+}
+
+func (codec *bitfieldCodec) Marshal(value FieldValues) ([]byte, error) {
+	var v uint64
+	for name, fieldValue := range value {
+		var err error
+		v, err = codec.layout.Set(v, name, fieldValue)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return codec.codec.Marshal(v)
+}
+
+func (codec *bitfieldCodec) Unmarshal(data []byte) (FieldValues, error) {
+	v, err := codec.codec.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	values := make(FieldValues, len(codec.layout.Fields))
+	for _, fv := range codec.layout.Decode(v) {
+		values[fv.Name] = fv.Value
+	}
+	return values, nil
+}
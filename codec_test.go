@@ -0,0 +1,319 @@
+package pac194x5x
+
+import (
+	"errors"
+	"maps"
+	"testing"
+)
+
+func TestGetSetField(t *testing.T) {
+	tests := []struct {
+		name  string
+		field Field
+		v     uint64
+		get   uint64
+		set   uint64
+		want  uint64
+	}{
+		{"width 1 low", Field{Position: 0, Width: 1}, 0b1010, 0, 1, 0b1011},
+		{"width 1 high", Field{Position: 7, Width: 1}, 0x80, 1, 0, 0x00},
+		{"width 2", Field{Position: 6, Width: 2}, 0b1100_0000, 3, 1, 0b0100_0000},
+		{"width 4", Field{Position: 12, Width: 4}, 0xa5ff, 0xa, 0x3, 0x35ff},
+		{"width 4 truncated", Field{Position: 12, Width: 4}, 0x0000, 0, 0x1f, 0xf000},
+		{"width 63", Field{Position: 1, Width: 63}, 0xffff_ffff_ffff_fffe, 0x7fff_ffff_ffff_ffff, 0, 0},
+		{"width 64", Field{Position: 0, Width: 64}, 0xffff_ffff_ffff_ffff, 0xffff_ffff_ffff_ffff, 0x0123, 0x0123},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetField(tt.v, tt.field); got != tt.get {
+				t.Errorf("GetField() = %#x, want %#x", got, tt.get)
+			}
+			if got := SetField(tt.v, tt.field, tt.set); got != tt.want {
+				t.Errorf("SetField() = %#x, want %#x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetSetFieldRegisters(t *testing.T) {
+	// CTRL with sample mode 0b0101 and channels 2 and 4 off.
+	var ctrl uint16 = 0x5050
+	if got := GetField(ctrl, SampleModeField); got != 0b0101 {
+		t.Errorf("SAMPLE_MODE = %d, want 5", got)
+	}
+	for channelNo, want := range []uint16{0, 1, 0, 1} {
+		if got := GetField(ctrl, ChannelOffFields[channelNo]); got != want {
+			t.Errorf("CHANNEL_%d_OFF = %d, want %d", channelNo+1, got, want)
+		}
+	}
+
+	var negPwrFsr uint16
+	negPwrFsr = SetField(negPwrFsr, VSenseRangeFields[1], uint16(RangeBipolarHalf))
+	negPwrFsr = SetField(negPwrFsr, VBusRangeFields[3], uint16(RangeBipolar))
+	if negPwrFsr != 0x2001 {
+		t.Errorf("NEG_PWR_FSR = %#04x, want 0x2001", negPwrFsr)
+	}
+
+	var accumConfig uint8 = 0xff
+	accumConfig = SetField(accumConfig, AccumConfigFields[0], uint8(AccumModeVSense))
+	if accumConfig != 0x7f {
+		t.Errorf("ACCUM_CONFIG = %#02x, want 0x7f", accumConfig)
+	}
+}
+
+func TestLayoutSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   string
+		value   uint64
+		want    uint64
+		wantErr error
+	}{
+		{"max", "SAMPLE_MODE", 0xf, 0xf000, nil},
+		{"width 1", "CHANNEL_3_OFF", 1, 0x0020, nil},
+		{"too wide", "SAMPLE_MODE", 0x10, 0, ErrCodecRange},
+		{"width 1 too wide", "CHANNEL_1_OFF", 2, 0, ErrCodecRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CtrlLayout.Set(0, tt.field, tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Set() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Set() = %#x, want %#x", got, tt.want)
+			}
+		})
+	}
+
+	_, err := CtrlLayout.Set(0, "NO_SUCH_FIELD", 0)
+	if err == nil {
+		t.Error("Set() of unknown field succeeded")
+	}
+	_, err = CtrlLayout.Get(0, "NO_SUCH_FIELD")
+	if err == nil {
+		t.Error("Get() of unknown field succeeded")
+	}
+}
+
+func TestNewLayoutPanics(t *testing.T) {
+	tests := []struct {
+		name   string
+		length int
+		fields []Field
+	}{
+		{"overlap", 1, []Field{{Name: "A", Position: 4, Width: 4}, {Name: "B", Position: 0, Width: 5}}},
+		{"outside", 1, []Field{{Name: "A", Position: 6, Width: 4}}},
+		{"negative position", 2, []Field{{Name: "A", Position: -1, Width: 2}}},
+		{"zero width", 2, []Field{{Name: "A", Position: 0, Width: 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("NewLayout() did not panic")
+				}
+			}()
+			NewLayout(tt.length, tt.fields...)
+		})
+	}
+
+	// A single 64-bit field fills an 8-byte register.
+	NewLayout(8, Field{Name: "ALL", Position: 0, Width: 64})
+}
+
+func TestLayoutFormat(t *testing.T) {
+	got := AccumConfigLayout.Format(0b01_10_00_11)
+	want := "ACC1_CONFIG=0x1 ACC2_CONFIG=0x2 ACC3_CONFIG=0x0 ACC4_CONFIG=0x3"
+	if got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+}
+
+func TestBitfieldCodec(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout Layout
+		values FieldValues
+		data   []byte
+	}{
+		{
+			name:   "ctrl",
+			layout: CtrlLayout,
+			values: FieldValues{"SAMPLE_MODE": 0x8, "GPIO_ALERT2": 0x1, "SLOW_ALERT1": 0x3, "CHANNEL_4_OFF": 1},
+			data:   []byte{0x87, 0x10},
+		},
+		{
+			name:   "neg_pwr_fsr",
+			layout: NegPwrFsrLayout,
+			values: FieldValues{"CFG_VS1": 2, "CFG_VB4": 1},
+			data:   []byte{0x80, 0x01},
+		},
+		{
+			name:   "accum_config",
+			layout: AccumConfigLayout,
+			values: FieldValues{"ACC2_CONFIG": 2},
+			data:   []byte{0x20},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codec := NewBitfieldCodec(tt.layout)
+			data, err := codec.Marshal(tt.values)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(data) != string(tt.data) {
+				t.Fatalf("Marshal() = %x, want %x", data, tt.data)
+			}
+
+			values, err := codec.Unmarshal(data)
+			if err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			// Unmarshal reports every field, including those left zero.
+			want := make(FieldValues)
+			for _, f := range tt.layout.Fields {
+				want[f.Name] = tt.values[f.Name]
+			}
+			if !maps.Equal(values, want) {
+				t.Errorf("Unmarshal() = %v, want %v", values, want)
+			}
+		})
+	}
+
+	codec := NewBitfieldCodec(CtrlLayout)
+	_, err := codec.Marshal(FieldValues{"SAMPLE_MODE": 0x10})
+	if !errors.Is(err, ErrCodecRange) {
+		t.Errorf("Marshal() of too wide value error = %v, want %v", err, ErrCodecRange)
+	}
+	_, err = codec.Unmarshal([]byte{0x00})
+	if !errors.Is(err, ErrCodecLength) {
+		t.Errorf("Unmarshal() of short data error = %v, want %v", err, ErrCodecLength)
+	}
+}
+
+func TestBigEndianCodec(t *testing.T) {
+	tests := []struct {
+		name  string
+		codec Codec[int64]
+		data  []byte
+		value int64
+	}{
+		{"int56 zero", Int56Codec, []byte{0, 0, 0, 0, 0, 0, 0}, 0},
+		{"int56 max", Int56Codec, []byte{0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, 1<<55 - 1},
+		{"int56 min", Int56Codec, []byte{0x80, 0, 0, 0, 0, 0, 0}, -1 << 55},
+		{"int56 -1", Int56Codec, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, -1},
+		{"int24 max", NewBigEndianCodec[int64](3), []byte{0x7f, 0xff, 0xff}, 1<<23 - 1},
+		{"int24 min", NewBigEndianCodec[int64](3), []byte{0x80, 0x00, 0x00}, -1 << 23},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := tt.codec.Unmarshal(tt.data)
+			if err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if value != tt.value {
+				t.Errorf("Unmarshal() = %d, want %d", value, tt.value)
+			}
+			data, err := tt.codec.Marshal(tt.value)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(data) != string(tt.data) {
+				t.Errorf("Marshal() = %x, want %x", data, tt.data)
+			}
+		})
+	}
+}
+
+func TestFixedWidthSignedCodecs(t *testing.T) {
+	int16Tests := []struct {
+		data  []byte
+		value int16
+	}{
+		{[]byte{0x7f, 0xff}, 32767},
+		{[]byte{0x80, 0x00}, -32768},
+		{[]byte{0xff, 0xff}, -1},
+		{[]byte{0x00, 0x01}, 1},
+	}
+	for _, tt := range int16Tests {
+		value, err := Int16Codec.Unmarshal(tt.data)
+		if (err != nil) || (value != tt.value) {
+			t.Errorf("Int16Codec.Unmarshal(%x) = %d, %v, want %d", tt.data, value, err, tt.value)
+		}
+	}
+
+	int32Tests := []struct {
+		data  []byte
+		value int32
+	}{
+		{[]byte{0x7f, 0xff, 0xff, 0xff}, 1<<31 - 1},
+		{[]byte{0x80, 0x00, 0x00, 0x00}, -1 << 31},
+		{[]byte{0xff, 0xff, 0xff, 0xfe}, -2},
+	}
+	for _, tt := range int32Tests {
+		value, err := Int32Codec.Unmarshal(tt.data)
+		if (err != nil) || (value != tt.value) {
+			t.Errorf("Int32Codec.Unmarshal(%x) = %d, %v, want %d", tt.data, value, err, tt.value)
+		}
+	}
+
+	value, err := Uint24Codec.Unmarshal([]byte{0xff, 0xff, 0xff})
+	if (err != nil) || (value != 0xffffff) {
+		t.Errorf("Uint24Codec.Unmarshal(ffffff) = %d, %v, want %d", value, err, 0xffffff)
+	}
+}
+
+func TestBigEndianCodecErrors(t *testing.T) {
+	_, err := Int56Codec.Marshal(1 << 55)
+	if !errors.Is(err, ErrCodecRange) {
+		t.Errorf("Int56Codec.Marshal(1<<55) error = %v, want %v", err, ErrCodecRange)
+	}
+	_, err = Int56Codec.Marshal(-1<<55 - 1)
+	if !errors.Is(err, ErrCodecRange) {
+		t.Errorf("Int56Codec.Marshal(-1<<55-1) error = %v, want %v", err, ErrCodecRange)
+	}
+	_, err = Uint24Codec.Marshal(1 << 24)
+	if !errors.Is(err, ErrCodecRange) {
+		t.Errorf("Uint24Codec.Marshal(1<<24) error = %v, want %v", err, ErrCodecRange)
+	}
+	_, err = Int16Codec.Unmarshal([]byte{0x00})
+	if !errors.Is(err, ErrCodecLength) {
+		t.Errorf("Int16Codec.Unmarshal() of 1 byte error = %v, want %v", err, ErrCodecLength)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("NewBigEndianCodec[int16](3) did not panic")
+		}
+	}()
+	NewBigEndianCodec[int16](3)
+}
+
+func TestDecodeSigned(t *testing.T) {
+	tests := []struct {
+		name  string
+		got   func() (int64, error)
+		value int64
+	}{
+		{"vbus unipolar", func() (int64, error) { return decodeSigned(uint16(0x8000), false, Uint16Codec, Int16Codec) }, 0x8000},
+		{"vbus bipolar", func() (int64, error) { return decodeSigned(uint16(0x8000), true, Uint16Codec, Int16Codec) }, -32768},
+		{"vpower bipolar", func() (int64, error) { return decodeSigned(uint32(0xfffffffc), true, Uint32Codec, Int32Codec) }, -4},
+		{"vacc unipolar", func() (int64, error) {
+			return decodeSigned(uint64(0xff_ffff_ffff_ffff), false, Uint64Codec, Int56Codec)
+		}, 1<<56 - 1},
+		{"vacc bipolar", func() (int64, error) { return decodeSigned(uint64(0xff_ffff_ffff_ffff), true, Uint64Codec, Int56Codec) }, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := tt.got()
+			if err != nil {
+				t.Fatalf("decodeSigned() error = %v", err)
+			}
+			if value != tt.value {
+				t.Errorf("decodeSigned() = %d, want %d", value, tt.value)
+			}
+		})
+	}
+}
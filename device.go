@@ -1,9 +1,9 @@
 package pac194x5x
 
 import (
-	"encoding/binary"
 	"fmt"
 	"math"
+	"sync"
 	"time"
 
 	"periph.io/x/conn/v3/i2c"
@@ -15,24 +15,56 @@ const (
 )
 
 // Dev is a handle for a configured PAC194x5x device.
+//
+// Dev is safe for concurrent use. Each method call is atomic with respect to other callers; use Transaction to
+// group several calls, e.g. a refresh followed by reads.
 type Dev struct {
+	*device
+	held bool // held is true if the caller holds device.mu.
+}
+
+type device struct {
+	mu   sync.Mutex
+	view *Dev // view is the Dev used while mu is held.
+
 	i2cDev       *i2c.Dev
 	voltageRatio []float64
 	rSense       []float64
 	productID    ProductID
 	isPAC5x      bool
 	channelCount int
+	cache        *registerCache
+	pec          bool
+
+	virtualChannels []VirtualChannel
+	calibration     []ChannelCalibration
+	shunts          map[int]Shunt
+	shuntTemps      map[int]float64 // shuntTemps caches the shunt temperatures until the next refresh.
+
+	configChanged    bool
+	accumulators     [4]accumulatorState
+	accResets        uint64
+	accOverflowAlert bool
+
+	retryPolicy   RetryPolicy
+	retryCounters retryCounters
 }
 
-// NewI2C initializes a power monitor through I2C connection.
-func NewI2C(b i2c.Bus, addr uint16, voltageRatio []float64, rSense []float64) (*Dev, error) {
+// NewI2C initializes a power monitor through I2C connection. voltageRatio and rSense must hold a positive value
+// for every channel of the detected product, otherwise a *ParameterError is returned.
+func NewI2C(b i2c.Bus, addr uint16, voltageRatio []float64, rSense []float64, opts ...Option) (*Dev, error) {
 	dev := &Dev{
-		i2cDev: &i2c.Dev{
-			Addr: addr,
-			Bus:  b,
+		device: &device{
+			i2cDev: &i2c.Dev{
+				Addr: addr,
+				Bus:  b,
+			},
+			cache: newRegisterCache(),
 		},
-		voltageRatio: voltageRatio,
-		rSense:       rSense,
+	}
+	dev.view = &Dev{device: dev.device, held: true}
+	for _, opt := range opts {
+		opt(dev)
 	}
 
 	productID, err := dev.GetProductID()
@@ -80,12 +112,34 @@ func NewI2C(b i2c.Bus, addr uint16, voltageRatio []float64, rSense []float64) (*
 		dev.isPAC5x = true
 		dev.channelCount = 2
 	default:
-		return nil, fmt.Errorf("unknown product id: %dev", productID)
+		return nil, fmt.Errorf("unknown product id: %d", productID)
+	}
+
+	dev.voltageRatio, err = checkParameters("voltageRatio", voltageRatio, dev.channelCount)
+	if err != nil {
+		return nil, err
+	}
+	dev.rSense, err = checkParameters("rSense", rSense, dev.channelCount)
+	if err != nil {
+		return nil, err
+	}
+
+	dev.calibration = make([]ChannelCalibration, dev.channelCount)
+	for channelNo := range dev.calibration {
+		dev.calibration[channelNo] = DefaultChannelCalibration
 	}
 
 	return dev, nil
 }
 
+// Transaction runs fn with exclusive access to the device. Calls made through the Dev passed to fn are not
+// interleaved with calls from other goroutines. The Dev passed to fn must not be used after fn returns.
+func (dev *Dev) Transaction(fn func(dev *Dev) error) error {
+	dev, unlock := dev.acquire()
+	defer unlock()
+	return fn(dev)
+}
+
 // Channels returns the number of available channels.
 func (dev *Dev) Channels() int {
 	return dev.channelCount
@@ -93,22 +147,47 @@ func (dev *Dev) Channels() int {
 
 // GetCtrl returns the Ctrl register value.
 func (dev *Dev) GetCtrl() (uint16, error) {
-	return CtrlCacheRegister.Read(dev)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	return dev.cache.ctrl.Read(dev)
 }
 
 // SetCtrl sets the Ctrl register value.
 func (dev *Dev) SetCtrl(v uint16) error {
-	return CtrlCacheRegister.Write(dev, v)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.cache.ctrl.Write(dev, v)
+	if err != nil {
+		return err
+	}
+	dev.configChanged = true
+	return nil
 }
 
 // GetAccCount returns the Acc_Count register value.
 func (dev *Dev) GetAccCount() (uint32, error) {
-	return AccCountCacheRegister.Read(dev)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	return dev.cache.accCount.Read(dev)
+}
+
+// GetAccReset returns the number of accumulator resets sent through the Dev, see Snapshot.AccReset.
+func (dev *Dev) GetAccReset() uint64 {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	return dev.accResets
 }
 
 // GetVAcc returns the Vacc_N register real data converted to W or V.
 func (dev *Dev) GetVAcc(channelNo int) (float64, UnitType, error) {
-	err := dev.checkChannelNo(channelNo)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.checkChannelActive(channelNo)
 	if err != nil {
 		return 0, Unknown, err
 	}
@@ -148,28 +227,36 @@ func (dev *Dev) GetVAcc(channelNo int) (float64, UnitType, error) {
 		unitType = Unknown
 	}
 
-	registers := []*CacheRegister[uint64]{VAcc1CacheRegister, VAcc2CacheRegister, VAcc3CacheRegister, VAcc4CacheRegister}
-	v, err := registers[channelNo].Read(dev)
+	v, err := dev.cache.vAcc[channelNo].Read(dev)
 	if err != nil {
 		return 0, Unknown, err
 	}
 
-	raw := float64(v)
-	if bidir {
-		b := binary.BigEndian.AppendUint64(nil, v)
-		if (b[1] & 0x80) == 0x80 {
-			b[0] = 0xff
-		}
-		v = binary.BigEndian.Uint64(b)
-		raw = float64(int64(v))
+	code, err := decodeSigned(v, bidir, Uint64Codec, Int56Codec)
+	if err != nil {
+		return 0, Unknown, err
 	}
+	raw := float64(code)
 
-	return raw * unit / dev.voltageRatio[channelNo], unitType, nil
+	accCount, err := dev.GetAccCount()
+	if err != nil {
+		return 0, Unknown, err
+	}
+
+	value, err := dev.calibrateAccumulated(channelNo, AccumMode(accumConfig), raw*unit/dev.voltageRatio[channelNo], accCount)
+	if err != nil {
+		return 0, Unknown, err
+	}
+
+	return value, unitType, nil
 }
 
 // GetVBus returns the Vbus_N register real data converted to V.
 func (dev *Dev) GetVBus(channelNo int) (float64, error) {
-	err := dev.checkChannelNo(channelNo)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.checkChannelActive(channelNo)
 	if err != nil {
 		return 0, err
 	}
@@ -184,23 +271,26 @@ func (dev *Dev) GetVBus(channelNo int) (float64, error) {
 		return 0, err
 	}
 
-	registers := []*CacheRegister[uint16]{VBus1CacheRegister, VBus2CacheRegister, VBus3CacheRegister, VBus4CacheRegister}
-	v, err := registers[channelNo].Read(dev)
+	v, err := dev.cache.vBus[channelNo].Read(dev)
 	if err != nil {
 		return 0, err
 	}
 
-	raw := float64(v)
-	if bidir {
-		raw = float64(int16(v))
+	code, err := decodeSigned(v, bidir, Uint16Codec, Int16Codec)
+	if err != nil {
+		return 0, err
 	}
+	raw := float64(code)
 
-	return raw * lsb / dev.voltageRatio[channelNo], nil
+	return dev.calibration[channelNo].Voltage.Apply(raw * lsb / dev.voltageRatio[channelNo]), nil
 }
 
 // GetVSense returns the Vsense_N register real data converted to mV.
 func (dev *Dev) GetVSense(channelNo int) (float64, error) {
-	err := dev.checkChannelNo(channelNo)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.checkChannelActive(channelNo)
 	if err != nil {
 		return 0, err
 	}
@@ -215,23 +305,26 @@ func (dev *Dev) GetVSense(channelNo int) (float64, error) {
 		return 0, err
 	}
 
-	registers := []*CacheRegister[uint16]{VSense1CacheRegister, VSense2CacheRegister, VSense3CacheRegister, VSense4CacheRegister}
-	v, err := registers[channelNo].Read(dev)
+	v, err := dev.cache.vSense[channelNo].Read(dev)
 	if err != nil {
 		return 0, err
 	}
 
-	raw := float64(v)
-	if bidir {
-		raw = float64(int16(v))
+	code, err := decodeSigned(v, bidir, Uint16Codec, Int16Codec)
+	if err != nil {
+		return 0, err
 	}
+	raw := float64(code)
 
-	return raw * lsb, nil
+	return dev.calibrateVSense(channelNo, raw*lsb)
 }
 
 // GetCurrent calculates the Current value using the Vsense_N register and the Rsense_N resistor value, reported in mA.
 func (dev *Dev) GetCurrent(channelNo int) (float64, error) {
-	err := dev.checkChannelNo(channelNo)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.checkChannelActive(channelNo)
 	if err != nil {
 		return 0, err
 	}
@@ -241,12 +334,20 @@ func (dev *Dev) GetCurrent(channelNo int) (float64, error) {
 		return 0, err
 	}
 
-	return v / dev.rSense[channelNo], nil
+	rSense, err := dev.getRSense(channelNo)
+	if err != nil {
+		return 0, err
+	}
+
+	return v / rSense, nil
 }
 
 // GetVBusAvg returns the Vbus_Avg_N register real data converted to V.
 func (dev *Dev) GetVBusAvg(channelNo int) (float64, error) {
-	err := dev.checkChannelNo(channelNo)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.checkChannelActive(channelNo)
 	if err != nil {
 		return 0, err
 	}
@@ -261,23 +362,26 @@ func (dev *Dev) GetVBusAvg(channelNo int) (float64, error) {
 		return 0, err
 	}
 
-	registers := []*CacheRegister[uint16]{VBus1AvgCacheRegister, VBus2AvgCacheRegister, VBus3AvgCacheRegister, VBus4AvgCacheRegister}
-	v, err := registers[channelNo].Read(dev)
+	v, err := dev.cache.vBusAvg[channelNo].Read(dev)
 	if err != nil {
 		return 0, err
 	}
 
-	raw := float64(v)
-	if bidir {
-		raw = float64(int16(v))
+	code, err := decodeSigned(v, bidir, Uint16Codec, Int16Codec)
+	if err != nil {
+		return 0, err
 	}
+	raw := float64(code)
 
-	return raw * lsb / dev.voltageRatio[channelNo], nil
+	return dev.calibration[channelNo].Voltage.Apply(raw * lsb / dev.voltageRatio[channelNo]), nil
 }
 
 // GetVSenseAvg returns the Vsense_Avg_N register real data converted to mV.
 func (dev *Dev) GetVSenseAvg(channelNo int) (float64, error) {
-	err := dev.checkChannelNo(channelNo)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.checkChannelActive(channelNo)
 	if err != nil {
 		return 0, err
 	}
@@ -292,23 +396,26 @@ func (dev *Dev) GetVSenseAvg(channelNo int) (float64, error) {
 		return 0, err
 	}
 
-	registers := []*CacheRegister[uint16]{VSense1AvgCacheRegister, VSense2AvgCacheRegister, VSense3AvgCacheRegister, VSense4AvgCacheRegister}
-	v, err := registers[channelNo].Read(dev)
+	v, err := dev.cache.vSenseAvg[channelNo].Read(dev)
 	if err != nil {
 		return 0, err
 	}
 
-	raw := float64(v)
-	if bidir {
-		raw = float64(int16(v))
+	code, err := decodeSigned(v, bidir, Uint16Codec, Int16Codec)
+	if err != nil {
+		return 0, err
 	}
+	raw := float64(code)
 
-	return raw * lsb, nil
+	return dev.calibrateVSense(channelNo, raw*lsb)
 }
 
 // GetCurrentAvg calculates the Current_Avg value using the Vsense_Avg_N register and the Rsense_N resistor value, reported in mA.
 func (dev *Dev) GetCurrentAvg(channelNo int) (float64, error) {
-	err := dev.checkChannelNo(channelNo)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.checkChannelActive(channelNo)
 	if err != nil {
 		return 0, err
 	}
@@ -318,12 +425,20 @@ func (dev *Dev) GetCurrentAvg(channelNo int) (float64, error) {
 		return 0, err
 	}
 
-	return v / dev.rSense[channelNo], nil
+	rSense, err := dev.getRSense(channelNo)
+	if err != nil {
+		return 0, err
+	}
+
+	return v / rSense, nil
 }
 
 // GetVPower gets the Vpower_N register real data converted to W.
 func (dev *Dev) GetVPower(channelNo int) (float64, error) {
-	err := dev.checkChannelNo(channelNo)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.checkChannelActive(channelNo)
 	if err != nil {
 		return 0, err
 	}
@@ -345,24 +460,40 @@ func (dev *Dev) GetVPower(channelNo int) (float64, error) {
 
 	bidir := bidirV || bidirI
 
-	registers := []*CacheRegister[uint32]{VPower1CacheRegister, VPower2CacheRegister, VPower3CacheRegister, VPower4CacheRegister}
-	v, err := registers[channelNo].Read(dev)
+	v, err := dev.cache.vPower[channelNo].Read(dev)
 	if err != nil {
 		return 0, err
 	}
 
-	raw := float64(v)
-	if bidir {
-		raw = float64(int32(v))
+	code, err := decodeSigned(v, bidir, Uint32Codec, Int32Codec)
+	if err != nil {
+		return 0, err
+	}
+	raw := float64(code) / 4
+
+	power := (raw * lsb) / dev.voltageRatio[channelNo]
+	if dev.calibration[channelNo] == DefaultChannelCalibration {
+		return power, nil
 	}
-	raw /= 4
 
-	return (raw * lsb) / dev.voltageRatio[channelNo], nil
+	vBus, err := dev.GetVBus(channelNo)
+	if err != nil {
+		return 0, err
+	}
+	current, err := dev.GetCurrent(channelNo)
+	if err != nil {
+		return 0, err
+	}
+
+	return dev.calibratePower(channelNo, power, vBus, current), nil
 }
 
 // GetEnergy calculates the Energy_N value (µWh) using the Vacc_N register real value.
 func (dev *Dev) GetEnergy(channelNo int) (float64, error) {
-	err := dev.checkChannelNo(channelNo)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.checkChannelActive(channelNo)
 	if err != nil {
 		return 0, err
 	}
@@ -406,131 +537,235 @@ func (dev *Dev) GetEnergy(channelNo int) (float64, error) {
 
 // GetNegPwrFsr returns the Neg_Pwr_Fsr register value.
 func (dev *Dev) GetNegPwrFsr() (uint16, error) {
-	return NegPwrFsrCacheRegister.Read(dev)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	return dev.cache.negPwrFsr.Read(dev)
 }
 
 // SetNegPwrFsr sets the Neg_Pwr_Fsr register value.
 func (dev *Dev) SetNegPwrFsr(v uint16) error {
-	return NegPwrFsrCacheRegister.Write(dev, v)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.cache.negPwrFsr.Write(dev, v)
+	if err != nil {
+		return err
+	}
+	dev.configChanged = true
+	return nil
 }
 
 // GetCtrlAct returns the Ctrl_Act register value.
 func (dev *Dev) GetCtrlAct() (uint16, error) {
-	return CtrlActCacheRegister.Read(dev)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	return dev.cache.ctrlAct.Read(dev)
 }
 
 // GetNegPwrFsrAct returns the Neg_Pwr_Fsr_Act register value.
 func (dev *Dev) GetNegPwrFsrAct() (uint16, error) {
-	return NegPwrFsrActCacheRegister.Read(dev)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	return dev.cache.negPwrFsrAct.Read(dev)
 }
 
 // GetCtrlLat returns the Ctrl_Lat register value.
 func (dev *Dev) GetCtrlLat() (uint16, error) {
-	return CtrlLatCacheRegister.Read(dev)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	return dev.cache.ctrlLat.Read(dev)
 }
 
 // GetNegPwrFsrLat returns the Neg_Pwr_Fsr_Lat register value.
 func (dev *Dev) GetNegPwrFsrLat() (uint16, error) {
-	return NegPwrFsrLatCacheRegister.Read(dev)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	return dev.cache.negPwrFsrLat.Read(dev)
 }
 
 // GetAccumConfig returns the Accum_Config register value.
 func (dev *Dev) GetAccumConfig() (uint8, error) {
-	return AccumConfigCacheRegister.Read(dev)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	return dev.cache.accumConfig.Read(dev)
 }
 
 // SetAccumConfig sets the Accum_Config register value.
 func (dev *Dev) SetAccumConfig(v uint8) error {
-	return AccumConfigCacheRegister.Write(dev, v)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.cache.accumConfig.Write(dev, v)
+	if err != nil {
+		return err
+	}
+	dev.configChanged = true
+	return nil
 }
 
 // Refresh sends a simple Refresh command to the device.
 func (dev *Dev) Refresh(delay time.Duration) error {
-	for _, cached := range cacheRegisters {
-		cached.Invalidate()
-	}
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	dev.invalidate()
 
 	err := dev.WriteRegister(RefreshRegister.Address, nil)
 	if err != nil {
 		return err
 	}
+	dev.configChanged = false
+	dev.resetAccumulatorState()
 	time.Sleep(delay)
 	return nil
 }
 
-// RefreshG sends a Refresh_G command to the device.
+// RefreshG sends a Refresh_G command to the device. Only this device is refreshed; use Bus.RefreshAll to send
+// it as a general call to every device on the bus.
 func (dev *Dev) RefreshG(delay time.Duration) error {
-	for _, cached := range cacheRegisters {
-		cached.Invalidate()
-	}
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	dev.invalidate()
 
 	err := dev.WriteRegister(RefreshGRegister.Address, nil)
 	if err != nil {
 		return err
 	}
+	dev.configChanged = false
+	dev.resetAccumulatorState()
 	time.Sleep(delay)
 	return nil
 }
 
 // RefreshV sends a Refresh_V command to the device.
 func (dev *Dev) RefreshV(delay time.Duration) error {
-	for _, cached := range cacheRegisters {
-		cached.Invalidate()
-	}
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	dev.invalidate()
 
 	err := dev.WriteRegister(RefreshVRegister.Address, nil)
 	if err != nil {
 		return err
 	}
+	dev.configChanged = false
 	time.Sleep(delay)
 	return nil
 }
 
 // GetAccumConfigAct returns the Accum_Config_Act register value.
 func (dev *Dev) GetAccumConfigAct() (uint8, error) {
-	return AccumConfigActCacheRegister.Read(dev)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	return dev.cache.accumConfigAct.Read(dev)
 }
 
 // GetAccumConfigLat returns the Accum_Config_Lat register value.
 func (dev *Dev) GetAccumConfigLat() (uint8, error) {
-	return AccumConfigLatCacheRegister.Read(dev)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	return dev.cache.accumConfigLat.Read(dev)
 }
 
 // GetProductID returns the product ID.
 func (dev *Dev) GetProductID() (ProductID, error) {
-	return ProductIDCacheRegister.Read(dev)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	return dev.cache.productID.Read(dev)
 }
 
 // GetManufacturerID returns the manufacturer ID.
 func (dev *Dev) GetManufacturerID() (uint8, error) {
-	return ManufacturerIDCacheRegister.Read(dev)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	return dev.cache.manufacturerID.Read(dev)
 }
 
 // GetRevisionID returns the revision ID.
 func (dev *Dev) GetRevisionID() (uint8, error) {
-	return RevisionIDCacheRegister.Read(dev)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	return dev.cache.revisionID.Read(dev)
 }
 
 // ReadRegister reads the register value.
 func (dev *Dev) ReadRegister(address uint8, len int) ([]byte, error) {
-	readBytes := make([]byte, len)
-	err := dev.i2cDev.Tx([]byte{address}, readBytes)
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	readLen := len
+	if dev.pec {
+		readLen++
+	}
+	readBytes := make([]byte, readLen)
+	err := dev.retry(address, func() error {
+		err := dev.i2cDev.Tx([]byte{address}, readBytes)
+		if err != nil {
+			return err
+		}
+		if dev.pec {
+			addr := uint8(dev.i2cDev.Addr << 1)
+			if pec([]byte{addr, address, addr | 0x01}, readBytes[:len]) != readBytes[len] {
+				return ErrPEC
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, err
+		return nil, newRegisterError(address, "read", err)
 	}
-	return readBytes, nil
+	return readBytes[:len], nil
 }
 
 // WriteRegister writes the value to the register.
 func (dev *Dev) WriteRegister(address uint8, data []byte) error {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := checkWrite(address, data)
+	if err != nil {
+		return newRegisterError(address, "write", err)
+	}
+
 	var writeBytes = []byte{address}
 	writeBytes = append(writeBytes, data...)
-	return dev.i2cDev.Tx([]byte{address}, nil)
+	if dev.pec {
+		writeBytes = append(writeBytes, pec([]byte{uint8(dev.i2cDev.Addr << 1)}, writeBytes))
+	}
+	err = dev.retry(address, func() error {
+		return dev.i2cDev.Tx(writeBytes, nil)
+	})
+	if err != nil {
+		return newRegisterError(address, "write", err)
+	}
+	return nil
+}
+
+// acquire locks the device unless the caller already holds the lock, and returns the Dev to be used while the
+// lock is held together with the function that releases it.
+func (dev *Dev) acquire() (*Dev, func()) {
+	if dev.held {
+		return dev, func() {}
+	}
+	dev.mu.Lock()
+	return dev.view, dev.mu.Unlock
 }
 
 func (dev *Dev) checkChannelNo(channelNo int) error {
-	if (channelNo < 0) || (channelNo > dev.channelCount) {
-		return fmt.Errorf("invalid channel no: %dev", channelNo)
+	if (channelNo < 0) || (channelNo >= dev.channelCount) {
+		return fmt.Errorf("%w: %d", ErrInvalidChannel, channelNo)
 	}
 	return nil
 }
@@ -590,9 +825,14 @@ func (dev *Dev) getPowerUnit(channelNo int) (float64, error) {
 		return 0, err
 	}
 
-	powerScaleLat := float64(100*9) / (dev.rSense[channelNo] * 1000)
+	rSense, err := dev.getRSense(channelNo)
+	if err != nil {
+		return 0, err
+	}
+
+	powerScaleLat := float64(100*9) / (rSense * 1000)
 	if dev.isPAC5x {
-		powerScaleLat = float64(100*32) / (dev.rSense[channelNo] * 1000)
+		powerScaleLat = float64(100*32) / (rSense * 1000)
 	}
 
 	bidirV, fsrV, err := dev.getBidirFsrVLat(channelNo)
@@ -625,7 +865,7 @@ func (dev *Dev) getBidirFsrVLat(channelNo int) (bool, bool, error) {
 		return false, false, err
 	}
 
-	bitsValue := getBitsValue(negPwrFsrLat, 2, 6-(channelNo*2))
+	bitsValue := GetField(negPwrFsrLat, VBusRangeFields[channelNo])
 	bidir := (bitsValue == 1) || (bitsValue == 2)
 	fsr := bitsValue != 2
 
@@ -643,7 +883,7 @@ func (dev *Dev) getBidirFsrILat(channelNo int) (bool, bool, error) {
 		return false, false, err
 	}
 
-	bitsValue := getBitsValue(negPwrFsrLat, 2, 14-(channelNo*2))
+	bitsValue := GetField(negPwrFsrLat, VSenseRangeFields[channelNo])
 	bidir := (bitsValue == 1) || (bitsValue == 2)
 	fsr := bitsValue != 2
 
@@ -661,7 +901,7 @@ func (dev *Dev) getChAccumConfig(channelNo int) (uint8, error) {
 		return 0, err
 	}
 
-	return getBitsValue(accumConfig, 2, 6-(channelNo*2)), nil
+	return GetField(accumConfig, AccumConfigFields[channelNo]), nil
 }
 
 func (dev *Dev) getSampleMode() (SampleMode, error) {
@@ -669,7 +909,7 @@ func (dev *Dev) getSampleMode() (SampleMode, error) {
 	if err != nil {
 		return 0, err
 	}
-	sampleMode := getBitsValue(v, 4, 12)
+	sampleMode := GetField(v, SampleModeField)
 	return SampleMode(sampleMode), nil
 }
 
@@ -701,42 +941,19 @@ func (dev *Dev) getSampleFrequency() (float64, error) {
 	case SampleModeSingleShot8x:
 		return math.NaN(), nil
 	case SampleModeFast, SampleModeBurst:
-		v, err := dev.GetCtrlLat()
+		activeChannels, err := dev.ActiveChannels()
 		if err != nil {
 			return 0, err
 		}
 
-		channelOnBits := getBitsValue(v, 4, 4)
-		activeChannels := 0
-		if (channelOnBits & 0x01) == 0 {
-			activeChannels++
-		}
-		if (channelOnBits & 0x02) == 0 {
-			activeChannels++
-		}
-		if (channelOnBits & 0x04) == 0 {
-			activeChannels++
-		}
-		if (channelOnBits & 0x08) == 0 {
-			activeChannels++
-		}
-		if activeChannels > dev.channelCount {
-			activeChannels = dev.channelCount
-		}
-
-		if activeChannels == 0 {
+		if len(activeChannels) == 0 {
 			return math.NaN(), nil
 		}
 
-		return (1024 * 5) / float64(activeChannels), nil
+		return (1024 * 5) / float64(len(activeChannels)), nil
 	case SampleModeSleep:
 		return math.NaN(), nil
 	default:
 		return math.NaN(), nil
 	}
 }
-
-func getBitsValue[T uint8 | uint16](v T, bits int, position int) T {
-	bitMask := (2 ^ bits) - 1
-	return (v >> T(position)) & T(bitMask)
-}
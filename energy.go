@@ -0,0 +1,118 @@
+package pac194x5x
+
+import (
+	"math"
+	"sync"
+)
+
+// EnergyTotals holds the forward and reverse totals of a channel.
+type EnergyTotals struct {
+	EnergyIn  float64 // EnergyIn is the consumed energy in µWh.
+	EnergyOut float64 // EnergyOut is the returned energy in µWh, as a positive value.
+	Net       float64 // Net is EnergyIn - EnergyOut in µWh.
+	ChargeIn  float64 // ChargeIn is the consumed charge in mAh.
+	ChargeOut float64 // ChargeOut is the returned charge in mAh, as a positive value.
+	NetCharge float64 // NetCharge is ChargeIn - ChargeOut in mAh.
+}
+
+// EnergyCounter splits the energy and charge of bidirectional channels into forward and reverse totals.
+//
+// Both are taken from the accumulator, which is signed when the channel is bipolar in NEG_PWR_FSR. Energy is
+// counted for channels in VPOWER accumulation mode and charge for channels in VSENSE accumulation mode (see
+// ConfigureCoulombCounting). The change of the accumulator between two snapshots is counted as forward or reverse
+// depending on its sign, so direction changes within one refresh interval are netted. Both Refresh and Refresh_V
+// may be used. A reset of the accumulators is detected from Snapshot.AccReset, and from a decrease of ACC_COUNT
+// for resets not sent through the Dev. Values accumulated between the previous snapshot and a reset are lost.
+//
+// EnergyCounter is safe for concurrent use.
+type EnergyCounter struct {
+	mu           sync.Mutex
+	started      bool
+	lastAccCount uint32
+	lastAccReset uint64
+	lastEnergy   map[int]float64
+	lastCharge   map[int]float64
+	totals       map[int]*EnergyTotals
+}
+
+// NewEnergyCounter creates an EnergyCounter.
+func NewEnergyCounter() *EnergyCounter {
+	return &EnergyCounter{
+		lastEnergy: make(map[int]float64),
+		lastCharge: make(map[int]float64),
+		totals:     make(map[int]*EnergyTotals),
+	}
+}
+
+// Add adds the measurements of snapshot. Snapshots must be added in order.
+func (ec *EnergyCounter) Add(snapshot Snapshot) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	accumulatorReset := !ec.started || (snapshot.AccReset != ec.lastAccReset) || (snapshot.AccCount < ec.lastAccCount)
+	for _, cs := range snapshot.Channels {
+		totals, ok := ec.totals[cs.Channel]
+		if !ok {
+			totals = &EnergyTotals{}
+			ec.totals[cs.Channel] = totals
+		}
+
+		energy := accumulatorDelta(ec.lastEnergy, cs.Channel, cs.Energy, accumulatorReset)
+		if energy > 0 {
+			totals.EnergyIn += energy
+		} else if energy < 0 {
+			totals.EnergyOut -= energy
+		}
+		totals.Net = totals.EnergyIn - totals.EnergyOut
+
+		charge := accumulatorDelta(ec.lastCharge, cs.Channel, cs.Charge, accumulatorReset)
+		if charge > 0 {
+			totals.ChargeIn += charge
+		} else if charge < 0 {
+			totals.ChargeOut -= charge
+		}
+		totals.NetCharge = totals.ChargeIn - totals.ChargeOut
+	}
+
+	ec.started = true
+	ec.lastAccCount = snapshot.AccCount
+	ec.lastAccReset = snapshot.AccReset
+}
+
+// accumulatorDelta returns the change of an accumulated value since the previous snapshot and records value. NaN
+// values, from channels in another accumulation mode, count as no change.
+func accumulatorDelta(last map[int]float64, channelNo int, value float64, reset bool) float64 {
+	if math.IsNaN(value) {
+		delete(last, channelNo)
+		return 0
+	}
+	delta := value
+	if previous, ok := last[channelNo]; ok && !reset {
+		delta -= previous
+	}
+	last[channelNo] = value
+	return delta
+}
+
+// Totals returns the totals of channelNo.
+func (ec *EnergyCounter) Totals(channelNo int) EnergyTotals {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	totals, ok := ec.totals[channelNo]
+	if !ok {
+		return EnergyTotals{}
+	}
+	return *totals
+}
+
+// Reset clears all totals.
+func (ec *EnergyCounter) Reset() {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	ec.started = false
+	ec.lastEnergy = make(map[int]float64)
+	ec.lastCharge = make(map[int]float64)
+	ec.totals = make(map[int]*EnergyTotals)
+}
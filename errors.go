@@ -0,0 +1,62 @@
+package pac194x5x
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidChannel   = errors.New("invalid channel")         // ErrInvalidChannel - channel number out of range.
+	ErrChannelDisabled  = errors.New("channel disabled")        // ErrChannelDisabled - channel is turned off.
+	ErrCodecLength      = errors.New("invalid data length")     // ErrCodecLength - codec received data of the wrong length.
+	ErrCodecRange       = errors.New("value out of range")      // ErrCodecRange - codec received a value too large for its length.
+	ErrReadOnly         = errors.New("register is read-only")   // ErrReadOnly - write to a read-only register.
+	ErrPEC              = errors.New("PEC mismatch")            // ErrPEC - SMBus Packet Error Checking failed.
+	ErrSamplerStarted   = errors.New("sampler already started") // ErrSamplerStarted - Sampler.Run called more than once.
+	ErrMissingParameter = errors.New("missing parameter")       // ErrMissingParameter - channel parameter not supplied.
+	ErrInvalidParameter = errors.New("invalid parameter")       // ErrInvalidParameter - channel parameter is zero, negative or not finite.
+)
+
+// RegisterError records a failed register operation.
+type RegisterError struct {
+	Address uint8  // Address of register.
+	Name    string // Datasheet name of register.
+	Op      string // Operation, "read" or "write".
+	Err     error  // Underlying error.
+}
+
+func (e *RegisterError) Error() string {
+	return fmt.Sprintf("%s %s (0x%02x): %v", e.Op, e.Name, e.Address, e.Err)
+}
+
+func (e *RegisterError) Unwrap() error {
+	return e.Err
+}
+
+func newRegisterError(address uint8, op string, err error) error {
+	return &RegisterError{
+		Address: address,
+		Name:    registerName(address),
+		Op:      op,
+		Err:     err,
+	}
+}
+
+// ParameterError records an invalid front-end parameter of a channel.
+type ParameterError struct {
+	Parameter string  // Parameter name, "voltageRatio" or "rSense".
+	Channel   int     // Channel number.
+	Value     float64 // Value supplied, if any.
+	Err       error   // ErrMissingParameter or ErrInvalidParameter.
+}
+
+func (e *ParameterError) Error() string {
+	if errors.Is(e.Err, ErrMissingParameter) {
+		return fmt.Sprintf("channel %d: %s: %v", e.Channel, e.Parameter, e.Err)
+	}
+	return fmt.Sprintf("channel %d: %s: %v: %g", e.Channel, e.Parameter, e.Err, e.Value)
+}
+
+func (e *ParameterError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,158 @@
+package pac194x5x
+
+import (
+	"math"
+)
+
+// Flags represents the validity of the measurements of a channel.
+type Flags uint16
+
+const (
+	FlagVSenseSaturatedHigh Flags = 1 << iota // FlagVSenseSaturatedHigh - VSENSE is at the highest code of its range.
+	FlagVSenseSaturatedLow                    // FlagVSenseSaturatedLow - VSENSE is at the lowest code of a bipolar range.
+	FlagVBusSaturatedHigh                     // FlagVBusSaturatedHigh - VBUS is at the highest code of its range.
+	FlagVBusSaturatedLow                      // FlagVBusSaturatedLow - VBUS is at the lowest code of a bipolar range.
+	FlagDisabled                              // FlagDisabled - channel is off. All values are NaN.
+	FlagAsleep                                // FlagAsleep - device is in sleep mode, so values are not updated.
+	FlagStale                                 // FlagStale - configuration changed since the last refresh.
+	FlagAccOverflow                           // FlagAccOverflow - accumulator overflowed since the last reset.
+)
+
+// Saturated reports whether VSENSE or VBUS is clipped.
+func (f Flags) Saturated() bool {
+	return f&(FlagVSenseSaturatedHigh|FlagVSenseSaturatedLow|FlagVBusSaturatedHigh|FlagVBusSaturatedLow) != 0
+}
+
+// Valid reports whether the instantaneous and average values can be trusted.
+func (f Flags) Valid() bool {
+	return !f.Saturated() && (f&(FlagDisabled|FlagAsleep|FlagStale) == 0)
+}
+
+// accumulatorState tracks an accumulator to detect overflow.
+type accumulatorState struct {
+	valid    bool
+	accCount uint32
+	vAcc     uint64
+	overflow bool
+}
+
+// channelFlags returns the validity flags of a channel whose values are cached by a preceding read.
+func (dev *Dev) channelFlags(channelNo int) (Flags, error) {
+	var flags Flags
+
+	sampleMode, err := dev.getSampleMode()
+	if err != nil {
+		return 0, err
+	}
+	if sampleMode == SampleModeSleep {
+		flags |= FlagAsleep
+	}
+	if dev.configChanged {
+		flags |= FlagStale
+	}
+
+	bidirV, _, err := dev.getBidirFsrVLat(channelNo)
+	if err != nil {
+		return 0, err
+	}
+	bidirI, _, err := dev.getBidirFsrILat(channelNo)
+	if err != nil {
+		return 0, err
+	}
+
+	vSense, err := dev.cache.vSense[channelNo].Read(dev)
+	if err != nil {
+		return 0, err
+	}
+	high, low := saturation(vSense, bidirI)
+	if high {
+		flags |= FlagVSenseSaturatedHigh
+	}
+	if low {
+		flags |= FlagVSenseSaturatedLow
+	}
+
+	vBus, err := dev.cache.vBus[channelNo].Read(dev)
+	if err != nil {
+		return 0, err
+	}
+	high, low = saturation(vBus, bidirV)
+	if high {
+		flags |= FlagVBusSaturatedHigh
+	}
+	if low {
+		flags |= FlagVBusSaturatedLow
+	}
+
+	overflow, err := dev.checkAccumulatorOverflow(channelNo, bidirV || bidirI)
+	if err != nil {
+		return 0, err
+	}
+	if overflow {
+		flags |= FlagAccOverflow
+	}
+
+	return flags, nil
+}
+
+// saturation reports whether a 16-bit code is at the highest or lowest code of its range. The lowest code of a
+// unipolar range is zero, which is a legitimate reading of an idle or switched-off rail, so it is not reported.
+func saturation(code uint16, bidir bool) (bool, bool) {
+	if bidir {
+		return code == 0x7fff, code == 0x8000
+	}
+	return code == 0xffff, false
+}
+
+// checkAccumulatorOverflow reports whether the accumulator of a channel overflowed since it was last reset. An
+// unsigned accumulator never decreases between resets, so a decrease while ACC_COUNT grows is an overflow. An
+// overflow reported by the ALERT_STATUS register is also taken into account.
+func (dev *Dev) checkAccumulatorOverflow(channelNo int, bidir bool) (bool, error) {
+	accCount, err := dev.GetAccCount()
+	if err != nil {
+		return false, err
+	}
+	vAcc, err := dev.cache.vAcc[channelNo].Read(dev)
+	if err != nil {
+		return false, err
+	}
+
+	state := &dev.accumulators[channelNo]
+	if state.valid && (accCount < state.accCount) {
+		// The accumulators were reset by a refresh not sent through this Dev.
+		state.overflow = false
+	} else if state.valid && !bidir && (vAcc < state.vAcc) {
+		state.overflow = true
+	}
+	state.valid = true
+	state.accCount = accCount
+	state.vAcc = vAcc
+	return state.overflow || dev.accOverflowAlert, nil
+}
+
+// resetAccumulatorState is called when the accumulators are reset.
+func (dev *Dev) resetAccumulatorState() {
+	dev.accResets++
+	for i := range dev.accumulators {
+		dev.accumulators[i] = accumulatorState{}
+	}
+	dev.accOverflowAlert = false
+}
+
+// nanChannelSnapshot returns a ChannelSnapshot without values.
+func nanChannelSnapshot(channelNo int, flags Flags) ChannelSnapshot {
+	nan := math.NaN()
+	return ChannelSnapshot{
+		Channel:    channelNo,
+		VBus:       nan,
+		VSense:     nan,
+		Current:    nan,
+		Power:      nan,
+		VBusAvg:    nan,
+		VSenseAvg:  nan,
+		CurrentAvg: nan,
+		Energy:     nan,
+		Charge:     nan,
+		Flags:      flags,
+	}
+}
@@ -0,0 +1,44 @@
+package pac194x5x
+
+import (
+	"testing"
+)
+
+func TestSaturation(t *testing.T) {
+	tests := []struct {
+		code     uint16
+		bidir    bool
+		wantHigh bool
+		wantLow  bool
+	}{
+		{0x0000, false, false, false},
+		{0x0001, false, false, false},
+		{0xffff, false, true, false},
+		{0x7fff, true, true, false},
+		{0x8000, true, false, true},
+		{0x0000, true, false, false},
+		{0xffff, true, false, false},
+	}
+	for _, tt := range tests {
+		high, low := saturation(tt.code, tt.bidir)
+		if (high != tt.wantHigh) || (low != tt.wantLow) {
+			t.Errorf("saturation(%#04x, %v) = %v, %v, want %v, %v", tt.code, tt.bidir, high, low, tt.wantHigh, tt.wantLow)
+		}
+	}
+}
+
+func TestIdleChannelValid(t *testing.T) {
+	dev, err := NewI2C(newFakeBus(PAC1942_1), 0x10, []float64{1, 1}, []float64{0.01, 0.01})
+	if err != nil {
+		t.Fatalf("NewI2C() error = %v", err)
+	}
+	snapshot, err := dev.ReadSnapshot()
+	if err != nil {
+		t.Fatalf("ReadSnapshot() error = %v", err)
+	}
+	for _, cs := range snapshot.Channels {
+		if !cs.Flags.Valid() {
+			t.Errorf("channel %d flags = %#x, want valid", cs.Channel, cs.Flags)
+		}
+	}
+}
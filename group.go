@@ -0,0 +1,252 @@
+package pac194x5x
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Rail identifies a channel of a device by a system-wide name.
+type Rail struct {
+	Name    string // Name of rail, e.g. "12V_IN".
+	Dev     *Dev   // Dev is the device measuring the rail.
+	Channel int    // Channel number.
+}
+
+// RailSnapshot holds the measurements of a rail. The embedded Energy is the energy since the previous refresh,
+// which resets the accumulators.
+type RailSnapshot struct {
+	Name        string  // Name of rail.
+	TotalEnergy float64 // TotalEnergy is the energy in µWh since the rail was added, or since Group.ResetEnergy.
+	ChannelSnapshot
+}
+
+// GroupSnapshot holds the measurements of all rails of a group.
+type GroupSnapshot struct {
+	Time        time.Time         // Time the values were latched.
+	Rails       []RailSnapshot    // Rails holds the per-rail measurements, in the order the rails were added.
+	TotalPower  float64           // TotalPower is the sum of the enabled rail powers in W.
+	TotalEnergy float64           // TotalEnergy is the sum of the rail TotalEnergy values in µWh.
+	Virtual     []VirtualSnapshot // Virtual holds the values of the virtual channels of the group.
+}
+
+// Group refreshes and reads named rails across several devices, possibly on different buses.
+//
+// Devices on a bus added with AddBus are refreshed with a single general call REFRESH_G. Other devices are sent
+// REFRESH_G individually. Every refresh resets the accumulators, so Group keeps a running energy total per rail,
+// in the same way as EnergyCounter. Energy accumulated between the last read and a refresh that was not sent by
+// the Group is lost. Group is safe for concurrent use.
+type Group struct {
+	mu      sync.Mutex
+	buses   []*Bus
+	rails   []Rail
+	virtual []VirtualChannel
+
+	energy     []railEnergy
+	lastEnergy map[int]float64
+}
+
+// railEnergy holds the running energy total of a rail.
+type railEnergy struct {
+	started  bool
+	accReset uint64
+	accCount uint32
+	total    float64
+}
+
+// NewGroup creates an empty Group.
+func NewGroup() *Group {
+	return &Group{
+		lastEnergy: make(map[int]float64),
+	}
+}
+
+// AddBus adds a bus whose registered devices are refreshed with a general call.
+func (g *Group) AddBus(bus *Bus) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.buses = append(g.buses, bus)
+}
+
+// AddRail adds a rail named name measured by channelNo of dev.
+func (g *Group) AddRail(name string, dev *Dev, channelNo int) error {
+	err := dev.checkChannelNo(channelNo)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, rail := range g.rails {
+		if rail.Name == name {
+			return fmt.Errorf("duplicate rail name: %s", name)
+		}
+	}
+	g.rails = append(g.rails, Rail{Name: name, Dev: dev, Channel: channelNo})
+	g.energy = append(g.energy, railEnergy{})
+	return nil
+}
+
+// Rails returns the rails.
+func (g *Group) Rails() []Rail {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return slices.Clone(g.rails)
+}
+
+// MeasureRail returns an expression that reads quantity of the rail named name, for use in the virtual channels
+// of the group, e.g. the efficiency of a converter whose input and output are measured by different devices.
+func (g *Group) MeasureRail(name string, quantity Quantity) (Expr, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for i, rail := range g.rails {
+		if rail.Name == name {
+			return Measure(i, quantity), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown rail: %s", name)
+}
+
+// AddVirtualChannel adds a virtual channel that is evaluated on every group snapshot. Expressions refer to rails
+// through MeasureRail.
+func (g *Group) AddVirtualChannel(name string, expr Expr) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	virtual, err := addVirtualChannel(g.virtual, name, expr, len(g.rails))
+	if err != nil {
+		return err
+	}
+	g.virtual = virtual
+	return nil
+}
+
+// ResetEnergy clears the running energy totals of all rails.
+func (g *Group) ResetEnergy() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	clear(g.energy)
+	g.lastEnergy = make(map[int]float64)
+}
+
+// Refresh sends REFRESH_G to every device of the group and waits once for delay.
+func (g *Group) Refresh(delay time.Duration) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err := g.refresh()
+	if err != nil {
+		return err
+	}
+	time.Sleep(delay)
+	return nil
+}
+
+// TakeSnapshot refreshes every device of the group and reads all rails.
+func (g *Group) TakeSnapshot(delay time.Duration) (GroupSnapshot, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	t := time.Now()
+	err := g.refresh()
+	if err != nil {
+		return GroupSnapshot{}, err
+	}
+	time.Sleep(delay)
+
+	snapshot, err := g.readSnapshot()
+	if err != nil {
+		return GroupSnapshot{}, err
+	}
+	snapshot.Time = t
+	return snapshot, nil
+}
+
+// ReadSnapshot reads all rails without refreshing.
+func (g *Group) ReadSnapshot() (GroupSnapshot, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.readSnapshot()
+}
+
+func (g *Group) refresh() error {
+	var refreshed []*Dev
+	for _, bus := range g.buses {
+		err := bus.RefreshAll(0)
+		if err != nil {
+			return err
+		}
+		refreshed = append(refreshed, bus.Devices()...)
+	}
+	for _, rail := range g.rails {
+		if slices.Contains(refreshed, rail.Dev) {
+			continue
+		}
+		err := rail.Dev.RefreshG(0)
+		if err != nil {
+			return err
+		}
+		refreshed = append(refreshed, rail.Dev)
+	}
+	return nil
+}
+
+func (g *Group) readSnapshot() (GroupSnapshot, error) {
+	snapshot := GroupSnapshot{
+		Time: time.Now(),
+	}
+	railSnapshot := Snapshot{
+		Time: snapshot.Time,
+	}
+	for i, rail := range g.rails {
+		var cs ChannelSnapshot
+		var accCount uint32
+		var accReset uint64
+		err := rail.Dev.Transaction(func(dev *Dev) error {
+			var err error
+			cs, err = dev.readChannelSnapshot(rail.Channel)
+			if err != nil {
+				return err
+			}
+			accCount, err = dev.GetAccCount()
+			accReset = dev.accResets
+			return err
+		})
+		if err != nil {
+			return GroupSnapshot{}, err
+		}
+		totalEnergy := g.addRailEnergy(i, cs.Energy, accCount, accReset)
+		snapshot.Rails = append(snapshot.Rails, RailSnapshot{Name: rail.Name, TotalEnergy: totalEnergy, ChannelSnapshot: cs})
+		snapshot.TotalEnergy += totalEnergy
+		cs.Channel = i
+		railSnapshot.Channels = append(railSnapshot.Channels, cs)
+		if cs.Flags&FlagDisabled == 0 {
+			snapshot.TotalPower += cs.Power
+		}
+	}
+	virtual, err := evalVirtualChannels(g.virtual, railSnapshot)
+	if err != nil {
+		return GroupSnapshot{}, err
+	}
+	snapshot.Virtual = virtual
+	return snapshot, nil
+}
+
+// addRailEnergy adds the accumulated energy of rail railNo, read with the specified accumulator count and reset
+// sequence number, and returns the running total of the rail.
+func (g *Group) addRailEnergy(railNo int, energy float64, accCount uint32, accReset uint64) float64 {
+	re := &g.energy[railNo]
+	reset := !re.started || (accReset != re.accReset) || (accCount < re.accCount)
+	re.total += accumulatorDelta(g.lastEnergy, railNo, energy, reset)
+	re.started = true
+	re.accCount = accCount
+	re.accReset = accReset
+	return re.total
+}
@@ -0,0 +1,55 @@
+package pac194x5x
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGroupTotalEnergy(t *testing.T) {
+	bus := newFakeBus(PAC1942_1)
+	dev, err := NewI2C(bus, 0x10, []float64{1, 1}, []float64{0.01, 0.01})
+	if err != nil {
+		t.Fatalf("NewI2C() error = %v", err)
+	}
+	g := NewGroup()
+	err = g.AddRail("IN", dev, 0)
+	if err != nil {
+		t.Fatalf("AddRail() error = %v", err)
+	}
+
+	var energies []float64
+	for _, vAcc := range []byte{0x10, 0x30} {
+		bus.setRegister(VAcc1Register.Address, 0, 0, 0, 0, 0, vAcc, 0)
+		bus.setRegister(AccCountRegister.Address, 0, 0, 0, 1)
+		snapshot, err := g.TakeSnapshot(0)
+		if err != nil {
+			t.Fatalf("TakeSnapshot() error = %v", err)
+		}
+		energies = append(energies, snapshot.Rails[0].Energy)
+	}
+	if (energies[0] <= 0) || (energies[1] <= energies[0]) {
+		t.Fatalf("rail energies = %v, want increasing positive values", energies)
+	}
+	want := energies[0] + energies[1]
+
+	// A read without a refresh sees the same accumulator and adds nothing.
+	snapshot, err := g.ReadSnapshot()
+	if err != nil {
+		t.Fatalf("ReadSnapshot() error = %v", err)
+	}
+	if math.Abs(snapshot.Rails[0].TotalEnergy-want) > 1e-9*want {
+		t.Errorf("rail TotalEnergy = %v, want %v", snapshot.Rails[0].TotalEnergy, want)
+	}
+	if snapshot.TotalEnergy != snapshot.Rails[0].TotalEnergy {
+		t.Errorf("TotalEnergy = %v, want %v", snapshot.TotalEnergy, snapshot.Rails[0].TotalEnergy)
+	}
+
+	g.ResetEnergy()
+	snapshot, err = g.ReadSnapshot()
+	if err != nil {
+		t.Fatalf("ReadSnapshot() error = %v", err)
+	}
+	if snapshot.TotalEnergy != snapshot.Rails[0].Energy {
+		t.Errorf("TotalEnergy after ResetEnergy = %v, want %v", snapshot.TotalEnergy, snapshot.Rails[0].Energy)
+	}
+}
@@ -0,0 +1,27 @@
+package pac194x5x
+
+// WithPEC enables SMBus Packet Error Checking. A PEC byte is appended to every write and expected after every
+// read, and reads fail with ErrPEC if it does not match. Combine with WithRetryPolicy to re-read on mismatch.
+func WithPEC() Option {
+	return func(dev *Dev) {
+		dev.pec = true
+	}
+}
+
+// pec computes the SMBus PEC, a CRC-8 with polynomial x^8 + x^2 + x + 1, over data.
+func pec(data ...[]byte) uint8 {
+	var crc uint8
+	for _, b := range data {
+		for _, v := range b {
+			crc ^= v
+			for range 8 {
+				if crc&0x80 != 0 {
+					crc = (crc << 1) ^ 0x07
+				} else {
+					crc <<= 1
+				}
+			}
+		}
+	}
+	return crc
+}
@@ -0,0 +1,25 @@
+package pac194x5x
+
+import (
+	"testing"
+)
+
+func TestPEC(t *testing.T) {
+	tests := []struct {
+		name string
+		data [][]byte
+		want uint8
+	}{
+		{"empty", nil, 0x00},
+		{"check value", [][]byte{[]byte("123456789")}, 0xf4},
+		{"split", [][]byte{[]byte("1234"), []byte("56789")}, 0xf4},
+		{"single byte", [][]byte{{0x01}}, 0x07},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pec(tt.data...); got != tt.want {
+				t.Errorf("pec() = %#02x, want %#02x", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package pac194x5x
+
+// RawChannel holds the unconverted register codes of a channel together with the configuration needed to convert
+// them, so that recordings can be re-converted later without float rounding in the stored data.
+//
+// VBus, VSense and the averages are two's complement codes if the matching Bidir field is true. VPower is the
+// register code including its two unused low bits. VAcc is sign-extended according to AccumMode.
+type RawChannel struct {
+	Channel   int    // Channel number.
+	VBus      uint16 // VBus is the Vbus_N register code.
+	VSense    uint16 // VSense is the Vsense_N register code.
+	VBusAvg   uint16 // VBusAvg is the Vbus_Avg_N register code.
+	VSenseAvg uint16 // VSenseAvg is the Vsense_Avg_N register code.
+	VPower    uint32 // VPower is the Vpower_N register code.
+	VAcc      int64  // VAcc is the Vacc_N register code.
+	AccCount  uint32 // AccCount is the Acc_Count register code.
+
+	VSenseRange Range     // VSenseRange is the latched VSENSE range.
+	VBusRange   Range     // VBusRange is the latched VBUS range.
+	VSenseBidir bool      // VSenseBidir is true if the VSENSE codes are signed.
+	VBusBidir   bool      // VBusBidir is true if the VBUS codes are signed.
+	PowerBidir  bool      // PowerBidir is true if the VPOWER code is signed.
+	AccumMode   AccumMode // AccumMode is the accumulation mode of the channel.
+	AccBidir    bool      // AccBidir is true if the VACC code is signed.
+
+	VBusLSB   float64 // VBusLSB in V per code, at the device pin.
+	VSenseLSB float64 // VSenseLSB in mV per code.
+	PowerLSB  float64 // PowerLSB in W per code, at the device pins, for the VPOWER code shifted right by 2.
+	AccLSB    float64 // AccLSB is the LSB of VAcc in the unit of AccumMode, at the device pins.
+
+	VoltageRatio float64 // VoltageRatio is the voltage divider ratio in use.
+	RSense       float64 // RSense is the sense resistance in Ω used for PowerLSB.
+}
+
+// GetRaw returns the register codes of a channel, the latched range configuration and the LSBs.
+func (dev *Dev) GetRaw(channelNo int) (RawChannel, error) {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.checkChannelActive(channelNo)
+	if err != nil {
+		return RawChannel{}, err
+	}
+
+	raw := RawChannel{
+		Channel:      channelNo,
+		VoltageRatio: dev.voltageRatio[channelNo],
+	}
+
+	negPwrFsrLat, err := dev.GetNegPwrFsrLat()
+	if err != nil {
+		return RawChannel{}, err
+	}
+	raw.VSenseRange = Range(GetField(negPwrFsrLat, VSenseRangeFields[channelNo]))
+	raw.VBusRange = Range(GetField(negPwrFsrLat, VBusRangeFields[channelNo]))
+
+	raw.VBusBidir, _, err = dev.getBidirFsrVLat(channelNo)
+	if err != nil {
+		return RawChannel{}, err
+	}
+	raw.VSenseBidir, _, err = dev.getBidirFsrILat(channelNo)
+	if err != nil {
+		return RawChannel{}, err
+	}
+	raw.PowerBidir = raw.VBusBidir || raw.VSenseBidir
+
+	raw.VBusLSB, err = dev.getVBusLSB(channelNo)
+	if err != nil {
+		return RawChannel{}, err
+	}
+	raw.VSenseLSB, err = dev.getVSenseLSB(channelNo)
+	if err != nil {
+		return RawChannel{}, err
+	}
+	raw.PowerLSB, err = dev.getPowerUnit(channelNo)
+	if err != nil {
+		return RawChannel{}, err
+	}
+	raw.RSense, err = dev.getRSense(channelNo)
+	if err != nil {
+		return RawChannel{}, err
+	}
+
+	accumConfig, err := dev.getChAccumConfig(channelNo)
+	if err != nil {
+		return RawChannel{}, err
+	}
+	raw.AccumMode = AccumMode(accumConfig)
+	switch raw.AccumMode {
+	case AccumModeVPower:
+		raw.AccBidir = raw.PowerBidir
+		raw.AccLSB = raw.PowerLSB
+	case AccumModeVSense:
+		raw.AccBidir = raw.VSenseBidir
+		raw.AccLSB = raw.VSenseLSB
+	case AccumModeVBus:
+		raw.AccBidir = raw.VBusBidir
+		raw.AccLSB = raw.VBusLSB
+	}
+
+	raw.VBus, err = dev.cache.vBus[channelNo].Read(dev)
+	if err != nil {
+		return RawChannel{}, err
+	}
+	raw.VSense, err = dev.cache.vSense[channelNo].Read(dev)
+	if err != nil {
+		return RawChannel{}, err
+	}
+	raw.VBusAvg, err = dev.cache.vBusAvg[channelNo].Read(dev)
+	if err != nil {
+		return RawChannel{}, err
+	}
+	raw.VSenseAvg, err = dev.cache.vSenseAvg[channelNo].Read(dev)
+	if err != nil {
+		return RawChannel{}, err
+	}
+	raw.VPower, err = dev.cache.vPower[channelNo].Read(dev)
+	if err != nil {
+		return RawChannel{}, err
+	}
+
+	vAcc, err := dev.cache.vAcc[channelNo].Read(dev)
+	if err != nil {
+		return RawChannel{}, err
+	}
+	raw.VAcc, err = decodeSigned(vAcc, raw.AccBidir, Uint64Codec, Int56Codec)
+	if err != nil {
+		return RawChannel{}, err
+	}
+
+	raw.AccCount, err = dev.GetAccCount()
+	if err != nil {
+		return RawChannel{}, err
+	}
+
+	return raw, nil
+}
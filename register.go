@@ -1,5 +1,9 @@
 package pac194x5x
 
+import (
+	"fmt"
+)
+
 // Register defines a PAC194x5x register.
 type Register[T any] struct {
 	Address uint8    // Address of register.
@@ -7,77 +11,140 @@ type Register[T any] struct {
 	Codec   Codec[T] // Codec used to marshal/unmarshal values.
 }
 
+// newRegister creates a Register from the register map entry named name, so that addresses and lengths are
+// defined only once.
+func newRegister[T any](name string, codec Codec[T]) Register[T] {
+	info, ok := LookupRegister(name)
+	if !ok {
+		panic(fmt.Sprintf("register %s not in register map", name))
+	}
+	return Register[T]{Address: info.Address, Length: info.Length, Codec: codec}
+}
+
+// Info returns the register map entry of the register.
+func (r Register[T]) Info() RegisterInfo {
+	info, _ := LookupRegisterAddress(r.Address)
+	return info
+}
+
+var (
+	RefreshRegister           = newRegister[Void]("REFRESH", VoidCodec)                 // RefreshRegister - REFRESH register.
+	CtrlRegister              = newRegister[uint16]("CTRL", Uint16Codec)                // CtrlRegister - CTRL register.
+	AccCountRegister          = newRegister[uint32]("ACC_COUNT", Uint32Codec)           // AccCountRegister - ACC_COUNT register.
+	VAcc1Register             = newRegister[uint64]("VACC1", Uint64Codec)               // VAcc1Register - VACC1 register.
+	VAcc2Register             = newRegister[uint64]("VACC2", Uint64Codec)               // VAcc2Register - VACC2 register.
+	VAcc3Register             = newRegister[uint64]("VACC3", Uint64Codec)               // VAcc3Register - VACC3 register.
+	VAcc4Register             = newRegister[uint64]("VACC4", Uint64Codec)               // VAcc4Register - VACC4 register.
+	VBus1Register             = newRegister[uint16]("VBUS1", Uint16Codec)               // VBus1Register - VBUS1 register.
+	VBus2Register             = newRegister[uint16]("VBUS2", Uint16Codec)               // VBus2Register - VBUS2 register.
+	VBus3Register             = newRegister[uint16]("VBUS3", Uint16Codec)               // VBus3Register - VBUS3 register.
+	VBus4Register             = newRegister[uint16]("VBUS4", Uint16Codec)               // VBus4Register - VBUS4 register.
+	VSense1Register           = newRegister[uint16]("VSENSE1", Uint16Codec)             // VSense1Register - VSENSE1 register.
+	VSense2Register           = newRegister[uint16]("VSENSE2", Uint16Codec)             // VSense2Register - VSENSE2 register.
+	VSense3Register           = newRegister[uint16]("VSENSE3", Uint16Codec)             // VSense3Register - VSENSE3 register.
+	VSense4Register           = newRegister[uint16]("VSENSE4", Uint16Codec)             // VSense4Register - VSENSE4 register.
+	VBus1AvgRegister          = newRegister[uint16]("VBUS1_AVG", Uint16Codec)           // VBus1AvgRegister - VBUS1_AVG register.
+	VBus2AvgRegister          = newRegister[uint16]("VBUS2_AVG", Uint16Codec)           // VBus2AvgRegister - VBUS2_AVG register.
+	VBus3AvgRegister          = newRegister[uint16]("VBUS3_AVG", Uint16Codec)           // VBus3AvgRegister - VBUS3_AVG register.
+	VBus4AvgRegister          = newRegister[uint16]("VBUS4_AVG", Uint16Codec)           // VBus4AvgRegister - VBUS4_AVG register.
+	VSense1AvgRegister        = newRegister[uint16]("VSENSE1_AVG", Uint16Codec)         // VSense1AvgRegister - VSENSE1_AVG register.
+	VSense2AvgRegister        = newRegister[uint16]("VSENSE2_AVG", Uint16Codec)         // VSense2AvgRegister - VSENSE2_AVG register.
+	VSense3AvgRegister        = newRegister[uint16]("VSENSE3_AVG", Uint16Codec)         // VSense3AvgRegister - VSENSE3_AVG register.
+	VSense4AvgRegister        = newRegister[uint16]("VSENSE4_AVG", Uint16Codec)         // VSense4AvgRegister - VSENSE4_AVG register.
+	VPower1Register           = newRegister[uint32]("VPOWER1", Uint32Codec)             // VPower1Register - VPOWER1 register.
+	VPower2Register           = newRegister[uint32]("VPOWER2", Uint32Codec)             // VPower2Register - VPOWER2 register.
+	VPower3Register           = newRegister[uint32]("VPOWER3", Uint32Codec)             // VPower3Register - VPOWER3 register.
+	VPower4Register           = newRegister[uint32]("VPOWER4", Uint32Codec)             // VPower4Register - VPOWER4 register.
+	SMBusRegister             = newRegister[uint8]("SMBUS SETTINGS", Uint8Codec)        // SMBusRegister - SMBUS SETTINGS register.
+	NegPwrFsrRegister         = newRegister[uint16]("NEG_PWR_FSR", Uint16Codec)         // NegPwrFsrRegister - NEG_PWR_FSR register.
+	RefreshGRegister          = newRegister[Void]("REFRESH_G", VoidCodec)               // RefreshGRegister - REFRESH_G register.
+	RefreshVRegister          = newRegister[Void]("REFRESH_V", VoidCodec)               // RefreshVRegister - REFRESH_V register.
+	SlowRegister              = newRegister[uint8]("SLOW", Uint8Codec)                  // SlowRegister - SLOW register.
+	CtrlActRegister           = newRegister[uint16]("CTRL_ACT", Uint16Codec)            // CtrlActRegister - CTRL_ACT register.
+	NegPwrFsrActRegister      = newRegister[uint16]("NEG_PWR_FSR_ACT", Uint16Codec)     // NegPwrFsrActRegister - NEG_PWR_FSR_ACT register.
+	CtrlLatRegister           = newRegister[uint16]("CTRL_LAT", Uint16Codec)            // CtrlLatRegister - CTRL_LAT register.
+	NegPwrFsrLatRegister      = newRegister[uint16]("NEG_PWR_FSR_LAT", Uint16Codec)     // NegPwrFsrLatRegister - NEG_PWR_FSR_LAT register.
+	AccumConfigRegister       = newRegister[uint8]("ACCUM CONFIG", Uint8Codec)          // AccumConfigRegister - ACCUM CONFIG register.
+	AlertStatusRegister       = newRegister[Alerts]("ALERT STATUS", AlertsCodec)        // AlertStatusRegister - ALERT STATUS register.
+	SlowAlert1Register        = newRegister[Alerts]("SLOW_ALERT1", AlertsCodec)         // SlowAlert1Register - SLOW_ALERT1 register.
+	GPIOAlert2Register        = newRegister[Alerts]("GPIO_ALERT2", AlertsCodec)         // GPIOAlert2Register - GPIO_ALERT2 register.
+	AccFullnessLimitsRegister = newRegister[uint16]("ACC_FULLNESS_LIMITS", Uint16Codec) // AccFullnessLimitsRegister - ACC_FULLNESS_LIMITS register.
+	OCLimit1Register          = newRegister[any]("OC LIMIT1", nil)                      // OCLimit1Register - OC LIMIT1 register.
+	OCLimit2Register          = newRegister[any]("OC LIMIT2", nil)                      // OCLimit2Register - OC LIMIT2 register.
+	OCLimit3Register          = newRegister[any]("OC LIMIT3", nil)                      // OCLimit3Register - OC LIMIT3 register.
+	OCLimit4Register          = newRegister[any]("OC LIMIT4", nil)                      // OCLimit4Register - OC LIMIT4 register.
+	UCLimit1Register          = newRegister[any]("UC LIMIT1", nil)                      // UCLimit1Register - UC LIMIT1 register.
+	UCLimit2Register          = newRegister[any]("UC LIMIT2", nil)                      // UCLimit2Register - UC LIMIT2 register.
+	UCLimit3Register          = newRegister[any]("UC LIMIT3", nil)                      // UCLimit3Register - UC LIMIT3 register.
+	UCLimit4Register          = newRegister[any]("UC LIMIT4", nil)                      // UCLimit4Register - UC LIMIT4 register.
+	OPLimit1Register          = newRegister[uint32]("OP LIMIT1", Uint24Codec)           // OPLimit1Register - OP LIMIT1 register.
+	OPLimit2Register          = newRegister[uint32]("OP LIMIT2", Uint24Codec)           // OPLimit2Register - OP LIMIT2 register.
+	OPLimit3Register          = newRegister[uint32]("OP LIMIT3", Uint24Codec)           // OPLimit3Register - OP LIMIT3 register.
+	OPLimit4Register          = newRegister[uint32]("OP LIMIT4", Uint24Codec)           // OPLimit4Register - OP LIMIT4 register.
+	OVLimit1Register          = newRegister[any]("OV LIMIT1", nil)                      // OVLimit1Register - OV LIMIT1 register.
+	OVLimit2Register          = newRegister[any]("OV LIMIT2", nil)                      // OVLimit2Register - OV LIMIT2 register.
+	OVLimit3Register          = newRegister[any]("OV LIMIT3", nil)                      // OVLimit3Register - OV LIMIT3 register.
+	OVLimit4Register          = newRegister[any]("OV LIMIT4", nil)                      // OVLimit4Register - OV LIMIT4 register.
+	UVLimit1Register          = newRegister[any]("UV LIMIT1", nil)                      // UVLimit1Register - UV LIMIT1 register.
+	UVLimit2Register          = newRegister[any]("UV LIMIT2", nil)                      // UVLimit2Register - UV LIMIT2 register.
+	UVLimit3Register          = newRegister[any]("UV LIMIT3", nil)                      // UVLimit3Register - UV LIMIT3 register.
+	UVLimit4Register          = newRegister[any]("UV LIMIT4", nil)                      // UVLimit4Register - UV LIMIT4 register.
+	OCLimitNSamplesRegister   = newRegister[uint8]("OC LIMIT NSAMPLES", Uint8Codec)     // OCLimitNSamplesRegister - OC LIMIT NSAMPLES register.
+	UCLimitNSamplesRegister   = newRegister[uint8]("UC LIMIT NSAMPLES", Uint8Codec)     // UCLimitNSamplesRegister - UC LIMIT NSAMPLES register.
+	OPLimitNSamplesRegister   = newRegister[uint8]("OP LIMIT NSAMPLES", Uint8Codec)     // OPLimitNSamplesRegister - OP LIMIT NSAMPLES register.
+	OVLimitNSamplesRegister   = newRegister[uint8]("OV LIMIT NSAMPLES", Uint8Codec)     // OVLimitNSamplesRegister - OV LIMIT NSAMPLES register.
+	UVLimitNSamplesRegister   = newRegister[uint8]("UV LIMIT NSAMPLES", Uint8Codec)     // UVLimitNSamplesRegister - UV LIMIT NSAMPLES register.
+	AlertEnableRegister       = newRegister[Alerts]("ALERT ENABLE", AlertsCodec)        // AlertEnableRegister - ALERT ENABLE register.
+	AccumConfigActRegister    = newRegister[uint8]("ACCUM CONFIG ACT", Uint8Codec)      // AccumConfigActRegister - ACCUM CONFIG ACT register.
+	AccumConfigLatRegister    = newRegister[uint8]("ACCUM CONFIG LAT", Uint8Codec)      // AccumConfigLatRegister - ACCUM CONFIG LAT register.
+	ProductIDRegister         = newRegister[ProductID]("PRODUCT ID", ProductIDCodec)    // ProductIDRegister - PRODUCT ID register.
+	ManufacturerIDRegister    = newRegister[uint8]("MANUFACTURER ID", Uint8Codec)       // ManufacturerIDRegister - MANUFACTURER ID register.
+	RevisionIDRegister        = newRegister[uint8]("REVISION ID", Uint8Codec)           // RevisionIDRegister - REVISION ID register.
+)
+
+var (
+	SampleModeField = Field{Name: "SAMPLE_MODE", Position: 12, Width: 4} // SampleModeField - SAMPLE_MODE field of the CTRL registers.
+	GPIOAlert2Field = Field{Name: "GPIO_ALERT2", Position: 10, Width: 2} // GPIOAlert2Field - GPIO_ALERT2 field of the CTRL registers.
+	SlowAlert1Field = Field{Name: "SLOW_ALERT1", Position: 8, Width: 2}  // SlowAlert1Field - SLOW_ALERT1 field of the CTRL registers.
+
+	// ChannelOffFields - CHANNEL_N_OFF fields of the CTRL registers, indexed by channel number.
+	ChannelOffFields = [4]Field{
+		{Name: "CHANNEL_1_OFF", Position: 7, Width: 1},
+		{Name: "CHANNEL_2_OFF", Position: 6, Width: 1},
+		{Name: "CHANNEL_3_OFF", Position: 5, Width: 1},
+		{Name: "CHANNEL_4_OFF", Position: 4, Width: 1},
+	}
+	// VSenseRangeFields - CFG_VSN fields of the NEG_PWR_FSR registers, indexed by channel number.
+	VSenseRangeFields = [4]Field{
+		{Name: "CFG_VS1", Position: 14, Width: 2},
+		{Name: "CFG_VS2", Position: 12, Width: 2},
+		{Name: "CFG_VS3", Position: 10, Width: 2},
+		{Name: "CFG_VS4", Position: 8, Width: 2},
+	}
+	// VBusRangeFields - CFG_VBN fields of the NEG_PWR_FSR registers, indexed by channel number.
+	VBusRangeFields = [4]Field{
+		{Name: "CFG_VB1", Position: 6, Width: 2},
+		{Name: "CFG_VB2", Position: 4, Width: 2},
+		{Name: "CFG_VB3", Position: 2, Width: 2},
+		{Name: "CFG_VB4", Position: 0, Width: 2},
+	}
+	// AccumConfigFields - ACCN_CONFIG fields of the ACCUM CONFIG registers, indexed by channel number.
+	AccumConfigFields = [4]Field{
+		{Name: "ACC1_CONFIG", Position: 6, Width: 2},
+		{Name: "ACC2_CONFIG", Position: 4, Width: 2},
+		{Name: "ACC3_CONFIG", Position: 2, Width: 2},
+		{Name: "ACC4_CONFIG", Position: 0, Width: 2},
+	}
+)
+
 var (
-	RefreshRegister           = Register[Void]{Address: 0x00, Length: 0, Codec: VoidCodec}           // RefreshRegister - REFRESH register.
-	CtrlRegister              = Register[uint16]{Address: 0x01, Length: 2, Codec: Uint16Codec}       // CtrlRegister - CTRL register.
-	AccCountRegister          = Register[uint32]{Address: 0x02, Length: 4, Codec: Uint32Codec}       // AccCountRegister - ACC_COUNT register.
-	VAcc1Register             = Register[uint64]{Address: 0x03, Length: 7, Codec: Uint64Codec}       // VAcc1Register - VACC1 register.
-	VAcc2Register             = Register[uint64]{Address: 0x04, Length: 7, Codec: Uint64Codec}       // VAcc2Register - VACC2 register.
-	VAcc3Register             = Register[uint64]{Address: 0x05, Length: 7, Codec: Uint64Codec}       // VAcc3Register - VACC3 register.
-	VAcc4Register             = Register[uint64]{Address: 0x06, Length: 7, Codec: Uint64Codec}       // VAcc4Register - VACC4 register.
-	VBus1Register             = Register[uint16]{Address: 0x07, Length: 2, Codec: Uint16Codec}       // VBus1Register - VBUS1 register.
-	VBus2Register             = Register[uint16]{Address: 0x08, Length: 2, Codec: Uint16Codec}       // VBus2Register - VBUS2 register.
-	VBus3Register             = Register[uint16]{Address: 0x09, Length: 2, Codec: Uint16Codec}       // VBus3Register - VBUS3 register.
-	VBus4Register             = Register[uint16]{Address: 0x0a, Length: 2, Codec: Uint16Codec}       // VBus4Register - VBUS4 register.
-	VSense1Register           = Register[uint16]{Address: 0x0b, Length: 2, Codec: Uint16Codec}       // VSense1Register - VSENSE1 register.
-	VSense2Register           = Register[uint16]{Address: 0x0c, Length: 2, Codec: Uint16Codec}       // VSense2Register - VSENSE2 register.
-	VSense3Register           = Register[uint16]{Address: 0x0d, Length: 2, Codec: Uint16Codec}       // VSense3Register - VSENSE3 register.
-	VSense4Register           = Register[uint16]{Address: 0x0e, Length: 2, Codec: Uint16Codec}       // VSense4Register - VSENSE4 register.
-	VBus1AvgRegister          = Register[uint16]{Address: 0x0f, Length: 2, Codec: Uint16Codec}       // VBus1AvgRegister - VBUS1_AVG register.
-	VBus2AvgRegister          = Register[uint16]{Address: 0x10, Length: 2, Codec: Uint16Codec}       // VBus2AvgRegister - VBUS2_AVG register.
-	VBus3AvgRegister          = Register[uint16]{Address: 0x11, Length: 2, Codec: Uint16Codec}       // VBus3AvgRegister - VBUS3_AVG register.
-	VBus4AvgRegister          = Register[uint16]{Address: 0x12, Length: 2, Codec: Uint16Codec}       // VBus4AvgRegister - VBUS4_AVG register.
-	VSense1AvgRegister        = Register[uint16]{Address: 0x13, Length: 2, Codec: Uint16Codec}       // VSense1AvgRegister - VSENSE1_AVG register.
-	VSense2AvgRegister        = Register[uint16]{Address: 0x14, Length: 2, Codec: Uint16Codec}       // VSense2AvgRegister - VSENSE2_AVG register.
-	VSense3AvgRegister        = Register[uint16]{Address: 0x15, Length: 2, Codec: Uint16Codec}       // VSense3AvgRegister - VSENSE3_AVG register.
-	VSense4AvgRegister        = Register[uint16]{Address: 0x16, Length: 2, Codec: Uint16Codec}       // VSense4AvgRegister - VSENSE4_AVG register.
-	VPower1Register           = Register[uint32]{Address: 0x17, Length: 4, Codec: Uint32Codec}       // VPower1Register - VPOWER1 register.
-	VPower2Register           = Register[uint32]{Address: 0x18, Length: 4, Codec: Uint32Codec}       // VPower2Register - VPOWER2 register.
-	VPower3Register           = Register[uint32]{Address: 0x19, Length: 4, Codec: Uint32Codec}       // VPower3Register - VPOWER3 register.
-	VPower4Register           = Register[uint32]{Address: 0x1a, Length: 4, Codec: Uint32Codec}       // VPower4Register - VPOWER4 register.
-	SMBusRegister             = Register[uint8]{Address: 0x1c, Length: 1, Codec: Uint8Codec}         // SMBusRegister - SMBUS SETTINGS register.
-	NegPwrFsrRegister         = Register[uint16]{Address: 0x1d, Length: 2, Codec: Uint16Codec}       // NegPwrFsrRegister - NEG_PWR_FSR register.
-	RefreshGRegister          = Register[Void]{Address: 0x1e, Length: 0, Codec: VoidCodec}           // RefreshGRegister - REFRESH_G register.
-	RefreshVRegister          = Register[Void]{Address: 0x1f, Length: 0, Codec: VoidCodec}           // RefreshVRegister - REFRESH_V register.
-	SlowRegister              = Register[uint8]{Address: 0x20, Length: 1, Codec: Uint8Codec}         // SlowRegister - SLOW register.
-	CtrlActRegister           = Register[uint16]{Address: 0x21, Length: 2, Codec: Uint16Codec}       // CtrlActRegister - CTRL_ACT register.
-	NegPwrFsrActRegister      = Register[uint16]{Address: 0x22, Length: 2, Codec: Uint16Codec}       // NegPwrFsrActRegister - NEG_PWR_FSR_ACT register.
-	CtrlLatRegister           = Register[uint16]{Address: 0x23, Length: 2, Codec: Uint16Codec}       // CtrlLatRegister - CTRL_LAT register.
-	NegPwrFsrLatRegister      = Register[uint16]{Address: 0x24, Length: 2, Codec: Uint16Codec}       // NegPwrFsrLatRegister - NEG_PWR_FSR_LAT register.
-	AccumConfigRegister       = Register[uint8]{Address: 0x25, Length: 1, Codec: Uint8Codec}         // AccumConfigRegister - ACCUM CONFIG register.
-	AlertStatusRegister       = Register[any]{Address: 0x26, Length: 3}                              // AlertStatusRegister - ALERT STATUS register.
-	SlowAlert1Register        = Register[any]{Address: 0x27, Length: 3}                              // SlowAlert1Register - SLOW_ALERT1 register.
-	GPIOAlert2Register        = Register[any]{Address: 0x28, Length: 3}                              // GPIOAlert2Register - GPIO_ALERT2 register.
-	AccFullnessLimitsRegister = Register[uint16]{Address: 0x29, Length: 2, Codec: Uint16Codec}       // AccFullnessLimitsRegister - ACC_FULLNESS_LIMITS register.
-	OCLimit1Register          = Register[any]{Address: 0x30, Length: 2}                              // OCLimit1Register - OC LIMIT1 register.
-	OCLimit2Register          = Register[any]{Address: 0x31, Length: 2}                              // OCLimit2Register - OC LIMIT2 register.
-	OCLimit3Register          = Register[any]{Address: 0x32, Length: 2}                              // OCLimit3Register - OC LIMIT3 register.
-	OCLimit4Register          = Register[any]{Address: 0x33, Length: 2}                              // OCLimit4Register - OC LIMIT4 register.
-	UCLimit1Register          = Register[any]{Address: 0x34, Length: 2}                              // UCLimit1Register - UC LIMIT1 register.
-	UCLimit2Register          = Register[any]{Address: 0x35, Length: 2}                              // UCLimit2Register - UC LIMIT2 register.
-	UCLimit3Register          = Register[any]{Address: 0x36, Length: 2}                              // UCLimit3Register - UC LIMIT3 register.
-	UCLimit4Register          = Register[any]{Address: 0x37, Length: 2}                              // UCLimit4Register - UC LIMIT4 register.
-	OPLimit1Register          = Register[any]{Address: 0x38, Length: 3}                              // OPLimit1Register - OP LIMIT1 register.
-	OPLimit2Register          = Register[any]{Address: 0x39, Length: 3}                              // OPLimit2Register - OP LIMIT2 register.
-	OPLimit3Register          = Register[any]{Address: 0x3a, Length: 3}                              // OPLimit3Register - OP LIMIT3 register.
-	OPLimit4Register          = Register[any]{Address: 0x3b, Length: 3}                              // OPLimit4Register - OP LIMIT4 register.
-	OVLimit1Register          = Register[any]{Address: 0x3c, Length: 2}                              // OVLimit1Register - OV LIMIT1 register.
-	OVLimit2Register          = Register[any]{Address: 0x3d, Length: 2}                              // OVLimit2Register - OV LIMIT2 register.
-	OVLimit3Register          = Register[any]{Address: 0x3e, Length: 2}                              // OVLimit3Register - OV LIMIT3 register.
-	OVLimit4Register          = Register[any]{Address: 0x3f, Length: 2}                              // OVLimit4Register - OV LIMIT4 register.
-	UVLimit1Register          = Register[any]{Address: 0x40, Length: 2}                              // UVLimit1Register - UV LIMIT1 register.
-	UVLimit2Register          = Register[any]{Address: 0x41, Length: 2}                              // UVLimit2Register - UV LIMIT2 register.
-	UVLimit3Register          = Register[any]{Address: 0x42, Length: 2}                              // UVLimit3Register - UV LIMIT3 register.
-	UVLimit4Register          = Register[any]{Address: 0x43, Length: 2}                              // UVLimit4Register - UV LIMIT4 register.
-	OCLimitNSamplesRegister   = Register[uint8]{Address: 0x44, Length: 1, Codec: Uint8Codec}         // OCLimitNSamplesRegister - OC LIMIT NSAMPLES register.
-	UCLimitNSamplesRegister   = Register[uint8]{Address: 0x45, Length: 1, Codec: Uint8Codec}         // UCLimitNSamplesRegister - UC LIMIT NSAMPLES register.
-	OPLimitNSamplesRegister   = Register[uint8]{Address: 0x46, Length: 1, Codec: Uint8Codec}         // OPLimitNSamplesRegister - OP LIMIT NSAMPLES register.
-	OVLimitNSamplesRegister   = Register[uint8]{Address: 0x47, Length: 1, Codec: Uint8Codec}         // OVLimitNSamplesRegister - OV LIMIT NSAMPLES register.
-	UVLimitNSamplesRegister   = Register[uint8]{Address: 0x48, Length: 1, Codec: Uint8Codec}         // UVLimitNSamplesRegister - UV LIMIT NSAMPLES register.
-	AlertEnableRegister       = Register[any]{Address: 0x49, Length: 3}                              // AlertEnableRegister - ALERT ENABLE register.
-	AccumConfigActRegister    = Register[uint8]{Address: 0x4a, Length: 1, Codec: Uint8Codec}         // AccumConfigActRegister - ACCUM CONFIG ACT register.
-	AccumConfigLatRegister    = Register[uint8]{Address: 0x4b, Length: 1, Codec: Uint8Codec}         // AccumConfigLatRegister - ACCUM CONFIG LAT register.
-	ProductIDRegister         = Register[ProductID]{Address: 0xfd, Length: 1, Codec: ProductIDCodec} // ProductIDRegister - PRODUCT ID register.
-	ManufacturerIDRegister    = Register[uint8]{Address: 0xfe, Length: 1, Codec: Uint8Codec}         // ManufacturerIDRegister - MANUFACTURER ID register.
-	RevisionIDRegister        = Register[uint8]{Address: 0xff, Length: 1, Codec: Uint8Codec}         // RevisionIDRegister - REVISION ID register.
+	// CtrlLayout - layout of the CTRL, CTRL_ACT and CTRL_LAT registers.
+	CtrlLayout = NewLayout(2, SampleModeField, GPIOAlert2Field, SlowAlert1Field,
+		ChannelOffFields[0], ChannelOffFields[1], ChannelOffFields[2], ChannelOffFields[3])
+	// NegPwrFsrLayout - layout of the NEG_PWR_FSR, NEG_PWR_FSR_ACT and NEG_PWR_FSR_LAT registers.
+	NegPwrFsrLayout = NewLayout(2,
+		VSenseRangeFields[0], VSenseRangeFields[1], VSenseRangeFields[2], VSenseRangeFields[3],
+		VBusRangeFields[0], VBusRangeFields[1], VBusRangeFields[2], VBusRangeFields[3])
+	// AccumConfigLayout - layout of the ACCUM CONFIG, ACCUM CONFIG ACT and ACCUM CONFIG LAT registers.
+	AccumConfigLayout = NewLayout(1, AccumConfigFields[0], AccumConfigFields[1], AccumConfigFields[2], AccumConfigFields[3])
 )
@@ -0,0 +1,169 @@
+package pac194x5x
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Access is the access mode of a register.
+type Access int
+
+const (
+	AccessReadOnly  Access = iota // AccessReadOnly - register can only be read.
+	AccessReadWrite               // AccessReadWrite - register can be read and written.
+	AccessCommand                 // AccessCommand - register is a command, sent without data.
+)
+
+func (access Access) String() string {
+	switch access {
+	case AccessReadOnly:
+		return "RO"
+	case AccessReadWrite:
+		return "RW"
+	case AccessCommand:
+		return "command"
+	default:
+		return fmt.Sprintf("Access(%d)", int(access))
+	}
+}
+
+// RegisterInfo describes a register of the register map.
+type RegisterInfo struct {
+	Address     uint8    // Address of register.
+	Name        string   // Datasheet name of register.
+	Length      int      // Length in bytes.
+	Access      Access   // Access mode.
+	ClearOnRead bool     // ClearOnRead is true if reading the register clears it.
+	Reset       uint64   // Reset is the power-on value. It is 0 for registers whose value depends on the part.
+	Layout      *Layout  // Layout of the bitfields, or nil if the register holds a single value.
+	Source      string   // Source is the name of the register this register shadows, if any.
+	Shadows     []string // Shadows are the names of the registers shadowing this register, in propagation order.
+	Description string   // Description of register.
+}
+
+// registers - register map, ordered by address. The Register vars are built from it.
+var registers = []RegisterInfo{
+	{Address: 0x00, Name: "REFRESH", Length: 0, Access: AccessCommand, Description: "Refresh: latch the readings and configuration, and reset the accumulators."},
+	{Address: 0x01, Name: "CTRL", Length: 2, Access: AccessReadWrite, Reset: 0x0700, Layout: &CtrlLayout, Shadows: []string{"CTRL_ACT", "CTRL_LAT"}, Description: "Control: sample mode, ALERT pin functions and channel enables."},
+	{Address: 0x02, Name: "ACC_COUNT", Length: 4, Access: AccessReadOnly, Description: "Accumulator count."},
+	{Address: 0x03, Name: "VACC1", Length: 7, Access: AccessReadOnly, Description: "Accumulator of channel 1."},
+	{Address: 0x04, Name: "VACC2", Length: 7, Access: AccessReadOnly, Description: "Accumulator of channel 2."},
+	{Address: 0x05, Name: "VACC3", Length: 7, Access: AccessReadOnly, Description: "Accumulator of channel 3."},
+	{Address: 0x06, Name: "VACC4", Length: 7, Access: AccessReadOnly, Description: "Accumulator of channel 4."},
+	{Address: 0x07, Name: "VBUS1", Length: 2, Access: AccessReadOnly, Description: "VBUS of channel 1."},
+	{Address: 0x08, Name: "VBUS2", Length: 2, Access: AccessReadOnly, Description: "VBUS of channel 2."},
+	{Address: 0x09, Name: "VBUS3", Length: 2, Access: AccessReadOnly, Description: "VBUS of channel 3."},
+	{Address: 0x0a, Name: "VBUS4", Length: 2, Access: AccessReadOnly, Description: "VBUS of channel 4."},
+	{Address: 0x0b, Name: "VSENSE1", Length: 2, Access: AccessReadOnly, Description: "VSENSE of channel 1."},
+	{Address: 0x0c, Name: "VSENSE2", Length: 2, Access: AccessReadOnly, Description: "VSENSE of channel 2."},
+	{Address: 0x0d, Name: "VSENSE3", Length: 2, Access: AccessReadOnly, Description: "VSENSE of channel 3."},
+	{Address: 0x0e, Name: "VSENSE4", Length: 2, Access: AccessReadOnly, Description: "VSENSE of channel 4."},
+	{Address: 0x0f, Name: "VBUS1_AVG", Length: 2, Access: AccessReadOnly, Description: "Rolling average of VBUS of channel 1."},
+	{Address: 0x10, Name: "VBUS2_AVG", Length: 2, Access: AccessReadOnly, Description: "Rolling average of VBUS of channel 2."},
+	{Address: 0x11, Name: "VBUS3_AVG", Length: 2, Access: AccessReadOnly, Description: "Rolling average of VBUS of channel 3."},
+	{Address: 0x12, Name: "VBUS4_AVG", Length: 2, Access: AccessReadOnly, Description: "Rolling average of VBUS of channel 4."},
+	{Address: 0x13, Name: "VSENSE1_AVG", Length: 2, Access: AccessReadOnly, Description: "Rolling average of VSENSE of channel 1."},
+	{Address: 0x14, Name: "VSENSE2_AVG", Length: 2, Access: AccessReadOnly, Description: "Rolling average of VSENSE of channel 2."},
+	{Address: 0x15, Name: "VSENSE3_AVG", Length: 2, Access: AccessReadOnly, Description: "Rolling average of VSENSE of channel 3."},
+	{Address: 0x16, Name: "VSENSE4_AVG", Length: 2, Access: AccessReadOnly, Description: "Rolling average of VSENSE of channel 4."},
+	{Address: 0x17, Name: "VPOWER1", Length: 4, Access: AccessReadOnly, Description: "Power of channel 1."},
+	{Address: 0x18, Name: "VPOWER2", Length: 4, Access: AccessReadOnly, Description: "Power of channel 2."},
+	{Address: 0x19, Name: "VPOWER3", Length: 4, Access: AccessReadOnly, Description: "Power of channel 3."},
+	{Address: 0x1a, Name: "VPOWER4", Length: 4, Access: AccessReadOnly, Description: "Power of channel 4."},
+	{Address: 0x1c, Name: "SMBUS SETTINGS", Length: 1, Access: AccessReadWrite, Description: "SMBus settings."},
+	{Address: 0x1d, Name: "NEG_PWR_FSR", Length: 2, Access: AccessReadWrite, Layout: &NegPwrFsrLayout, Shadows: []string{"NEG_PWR_FSR_ACT", "NEG_PWR_FSR_LAT"}, Description: "VSENSE and VBUS ranges."},
+	{Address: 0x1e, Name: "REFRESH_G", Length: 0, Access: AccessCommand, Description: "Refresh in response to a general call."},
+	{Address: 0x1f, Name: "REFRESH_V", Length: 0, Access: AccessCommand, Description: "Refresh without resetting the accumulators."},
+	{Address: 0x20, Name: "SLOW", Length: 1, Access: AccessReadWrite, Description: "SLOW pin status and refresh control."},
+	{Address: 0x21, Name: "CTRL_ACT", Length: 2, Access: AccessReadOnly, Reset: 0x0700, Layout: &CtrlLayout, Source: "CTRL", Description: "CTRL value in use."},
+	{Address: 0x22, Name: "NEG_PWR_FSR_ACT", Length: 2, Access: AccessReadOnly, Layout: &NegPwrFsrLayout, Source: "NEG_PWR_FSR", Description: "NEG_PWR_FSR value in use."},
+	{Address: 0x23, Name: "CTRL_LAT", Length: 2, Access: AccessReadOnly, Reset: 0x0700, Layout: &CtrlLayout, Source: "CTRL", Description: "CTRL value of the latched readings."},
+	{Address: 0x24, Name: "NEG_PWR_FSR_LAT", Length: 2, Access: AccessReadOnly, Layout: &NegPwrFsrLayout, Source: "NEG_PWR_FSR", Description: "NEG_PWR_FSR value of the latched readings."},
+	{Address: 0x25, Name: "ACCUM CONFIG", Length: 1, Access: AccessReadWrite, Layout: &AccumConfigLayout, Shadows: []string{"ACCUM CONFIG ACT", "ACCUM CONFIG LAT"}, Description: "Accumulation modes."},
+	{Address: 0x26, Name: "ALERT STATUS", Length: 3, Access: AccessReadOnly, ClearOnRead: true, Description: "Alert status, cleared on read."},
+	{Address: 0x27, Name: "SLOW_ALERT1", Length: 3, Access: AccessReadWrite, Description: "Alerts routed to the SLOW/ALERT1 pin."},
+	{Address: 0x28, Name: "GPIO_ALERT2", Length: 3, Access: AccessReadWrite, Description: "Alerts routed to the GPIO/ALERT2 pin."},
+	{Address: 0x29, Name: "ACC_FULLNESS_LIMITS", Length: 2, Access: AccessReadWrite, Description: "Accumulator and ACC_COUNT fullness limits."},
+	{Address: 0x30, Name: "OC LIMIT1", Length: 2, Access: AccessReadWrite, Reset: 0x7fff, Description: "Overcurrent limit of channel 1."},
+	{Address: 0x31, Name: "OC LIMIT2", Length: 2, Access: AccessReadWrite, Reset: 0x7fff, Description: "Overcurrent limit of channel 2."},
+	{Address: 0x32, Name: "OC LIMIT3", Length: 2, Access: AccessReadWrite, Reset: 0x7fff, Description: "Overcurrent limit of channel 3."},
+	{Address: 0x33, Name: "OC LIMIT4", Length: 2, Access: AccessReadWrite, Reset: 0x7fff, Description: "Overcurrent limit of channel 4."},
+	{Address: 0x34, Name: "UC LIMIT1", Length: 2, Access: AccessReadWrite, Reset: 0x8000, Description: "Undercurrent limit of channel 1."},
+	{Address: 0x35, Name: "UC LIMIT2", Length: 2, Access: AccessReadWrite, Reset: 0x8000, Description: "Undercurrent limit of channel 2."},
+	{Address: 0x36, Name: "UC LIMIT3", Length: 2, Access: AccessReadWrite, Reset: 0x8000, Description: "Undercurrent limit of channel 3."},
+	{Address: 0x37, Name: "UC LIMIT4", Length: 2, Access: AccessReadWrite, Reset: 0x8000, Description: "Undercurrent limit of channel 4."},
+	{Address: 0x38, Name: "OP LIMIT1", Length: 3, Access: AccessReadWrite, Reset: 0xffffff, Description: "Overpower limit of channel 1."},
+	{Address: 0x39, Name: "OP LIMIT2", Length: 3, Access: AccessReadWrite, Reset: 0xffffff, Description: "Overpower limit of channel 2."},
+	{Address: 0x3a, Name: "OP LIMIT3", Length: 3, Access: AccessReadWrite, Reset: 0xffffff, Description: "Overpower limit of channel 3."},
+	{Address: 0x3b, Name: "OP LIMIT4", Length: 3, Access: AccessReadWrite, Reset: 0xffffff, Description: "Overpower limit of channel 4."},
+	{Address: 0x3c, Name: "OV LIMIT1", Length: 2, Access: AccessReadWrite, Reset: 0x7fff, Description: "Overvoltage limit of channel 1."},
+	{Address: 0x3d, Name: "OV LIMIT2", Length: 2, Access: AccessReadWrite, Reset: 0x7fff, Description: "Overvoltage limit of channel 2."},
+	{Address: 0x3e, Name: "OV LIMIT3", Length: 2, Access: AccessReadWrite, Reset: 0x7fff, Description: "Overvoltage limit of channel 3."},
+	{Address: 0x3f, Name: "OV LIMIT4", Length: 2, Access: AccessReadWrite, Reset: 0x7fff, Description: "Overvoltage limit of channel 4."},
+	{Address: 0x40, Name: "UV LIMIT1", Length: 2, Access: AccessReadWrite, Reset: 0x8000, Description: "Undervoltage limit of channel 1."},
+	{Address: 0x41, Name: "UV LIMIT2", Length: 2, Access: AccessReadWrite, Reset: 0x8000, Description: "Undervoltage limit of channel 2."},
+	{Address: 0x42, Name: "UV LIMIT3", Length: 2, Access: AccessReadWrite, Reset: 0x8000, Description: "Undervoltage limit of channel 3."},
+	{Address: 0x43, Name: "UV LIMIT4", Length: 2, Access: AccessReadWrite, Reset: 0x8000, Description: "Undervoltage limit of channel 4."},
+	{Address: 0x44, Name: "OC LIMIT NSAMPLES", Length: 1, Access: AccessReadWrite, Description: "Consecutive samples required for an overcurrent alert."},
+	{Address: 0x45, Name: "UC LIMIT NSAMPLES", Length: 1, Access: AccessReadWrite, Description: "Consecutive samples required for an undercurrent alert."},
+	{Address: 0x46, Name: "OP LIMIT NSAMPLES", Length: 1, Access: AccessReadWrite, Description: "Consecutive samples required for an overpower alert."},
+	{Address: 0x47, Name: "OV LIMIT NSAMPLES", Length: 1, Access: AccessReadWrite, Description: "Consecutive samples required for an overvoltage alert."},
+	{Address: 0x48, Name: "UV LIMIT NSAMPLES", Length: 1, Access: AccessReadWrite, Description: "Consecutive samples required for an undervoltage alert."},
+	{Address: 0x49, Name: "ALERT ENABLE", Length: 3, Access: AccessReadWrite, Description: "Alert enables."},
+	{Address: 0x4a, Name: "ACCUM CONFIG ACT", Length: 1, Access: AccessReadOnly, Layout: &AccumConfigLayout, Source: "ACCUM CONFIG", Description: "ACCUM CONFIG value in use."},
+	{Address: 0x4b, Name: "ACCUM CONFIG LAT", Length: 1, Access: AccessReadOnly, Layout: &AccumConfigLayout, Source: "ACCUM CONFIG", Description: "ACCUM CONFIG value of the latched readings."},
+	{Address: 0xfd, Name: "PRODUCT ID", Length: 1, Access: AccessReadOnly, Description: "Product ID, depends on the part."},
+	{Address: 0xfe, Name: "MANUFACTURER ID", Length: 1, Access: AccessReadOnly, Reset: 0x54, Description: "Manufacturer ID."},
+	{Address: 0xff, Name: "REVISION ID", Length: 1, Access: AccessReadOnly, Description: "Revision ID, depends on the part."},
+}
+
+// Registers returns the register map, ordered by address.
+func Registers() []RegisterInfo {
+	return slices.Clone(registers)
+}
+
+// LookupRegister returns the register with the datasheet name name, ignoring case.
+func LookupRegister(name string) (RegisterInfo, bool) {
+	for _, info := range registers {
+		if strings.EqualFold(info.Name, name) {
+			return info, true
+		}
+	}
+	return RegisterInfo{}, false
+}
+
+// LookupRegisterAddress returns the register at address.
+func LookupRegisterAddress(address uint8) (RegisterInfo, bool) {
+	i, ok := slices.BinarySearchFunc(registers, address, func(info RegisterInfo, address uint8) int {
+		return int(info.Address) - int(address)
+	})
+	if !ok {
+		return RegisterInfo{}, false
+	}
+	return registers[i], true
+}
+
+// registerName returns the datasheet name of the register at address.
+func registerName(address uint8) string {
+	info, ok := LookupRegisterAddress(address)
+	if !ok {
+		return "register"
+	}
+	return info.Name
+}
+
+// checkWrite checks that data can be written to the register at address. Unknown addresses are not checked.
+func checkWrite(address uint8, data []byte) error {
+	info, ok := LookupRegisterAddress(address)
+	if !ok {
+		return nil
+	}
+	if info.Access == AccessReadOnly {
+		return ErrReadOnly
+	}
+	if len(data) != info.Length {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrCodecLength, info.Length, len(data))
+	}
+	return nil
+}
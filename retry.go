@@ -0,0 +1,89 @@
+package pac194x5x
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// RetryPolicy defines how failed register transfers are retried.
+type RetryPolicy struct {
+	MaxAttempts  int                  // MaxAttempts is the maximum number of attempts, including the first. Values below 1 are treated as 1.
+	Backoff      time.Duration        // Backoff is the delay before the first retry. It doubles on every subsequent retry.
+	MaxBackoff   time.Duration        // MaxBackoff caps the retry delay. Zero means no cap.
+	Retryable    func(err error) bool // Retryable reports whether err is transient. nil treats every error as transient.
+	RetryCommand func(err error) bool // RetryCommand reports whether a failed REFRESH, REFRESH_G or clear-on-read register read may be resent. nil never resends.
+}
+
+// RetryStats holds retry counters for diagnostics.
+type RetryStats struct {
+	Retries  uint64 // Retries is the number of retried transfers.
+	Failures uint64 // Failures is the number of transfers that failed after all attempts.
+}
+
+// Option configures a Dev.
+type Option func(dev *Dev)
+
+// WithRetryPolicy sets the retry policy used for register transfers.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(dev *Dev) {
+		dev.retryPolicy = policy
+	}
+}
+
+type retryCounters struct {
+	retries  atomic.Uint64
+	failures atomic.Uint64
+}
+
+// RetryStats returns the retry counters.
+func (dev *Dev) RetryStats() RetryStats {
+	return RetryStats{
+		Retries:  dev.retryCounters.retries.Load(),
+		Failures: dev.retryCounters.failures.Load(),
+	}
+}
+
+// retry runs tx according to the retry policy. Transfers to address that are not idempotent are only
+// resent if the policy's RetryCommand allows it.
+func (dev *Dev) retry(address uint8, tx func() error) error {
+	policy := dev.retryPolicy
+	retryable := policy.Retryable
+	if !isIdempotent(address) {
+		retryable = policy.RetryCommand
+		if retryable == nil {
+			retryable = func(error) bool { return false }
+		}
+	}
+
+	backoff := policy.Backoff
+	for attempt := 1; ; attempt++ {
+		err := tx()
+		if err == nil {
+			return nil
+		}
+		if (attempt >= policy.MaxAttempts) || ((retryable != nil) && !retryable(err)) {
+			dev.retryCounters.failures.Add(1)
+			return err
+		}
+		dev.retryCounters.retries.Add(1)
+		time.Sleep(backoff)
+		backoff *= 2
+		if (policy.MaxBackoff > 0) && (backoff > policy.MaxBackoff) {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
+
+// isIdempotent reports whether a transfer to address can be repeated without side effects. REFRESH and
+// REFRESH_G reset the accumulators, so a resend after a partial failure may discard accumulated data. A
+// clear-on-read register is cleared by the first read, even if the transfer failed afterwards, so a retry would
+// return the cleared value.
+func isIdempotent(address uint8) bool {
+	switch address {
+	case RefreshRegister.Address, RefreshGRegister.Address:
+		return false
+	default:
+		info, ok := LookupRegisterAddress(address)
+		return !ok || !info.ClearOnRead
+	}
+}
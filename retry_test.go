@@ -0,0 +1,60 @@
+package pac194x5x
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRetryClearOnRead(t *testing.T) {
+	bus := newFakeBus(PAC1942_1)
+	dev, err := NewI2C(bus, 0x10, []float64{1, 1}, []float64{0.01, 0.01},
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3}))
+	if err != nil {
+		t.Fatalf("NewI2C() error = %v", err)
+	}
+	errBus := errors.New("bus error")
+	bus.setErr(errBus)
+
+	_, err = dev.GetCtrl()
+	if !errors.Is(err, errBus) {
+		t.Errorf("GetCtrl() error = %v, want %v", err, errBus)
+	}
+	if n := bus.readCount(CtrlRegister.Address); n != 3 {
+		t.Errorf("CTRL reads = %d, want 3", n)
+	}
+
+	_, err = dev.GetAlertStatus()
+	if !errors.Is(err, errBus) {
+		t.Errorf("GetAlertStatus() error = %v, want %v", err, errBus)
+	}
+	if n := bus.readCount(AlertStatusRegister.Address); n != 1 {
+		t.Errorf("ALERT STATUS reads = %d, want 1", n)
+	}
+
+	err = dev.Refresh(0)
+	if !errors.Is(err, errBus) {
+		t.Errorf("Refresh() error = %v, want %v", err, errBus)
+	}
+	if n := bus.writeCount(RefreshRegister.Address); n != 1 {
+		t.Errorf("REFRESH writes = %d, want 1", n)
+	}
+
+	if stats := dev.RetryStats(); (stats.Retries != 2) || (stats.Failures != 3) {
+		t.Errorf("RetryStats() = %+v, want 2 retries and 3 failures", stats)
+	}
+}
+
+func TestRetryCommand(t *testing.T) {
+	bus := newFakeBus(PAC1942_1)
+	dev, err := NewI2C(bus, 0x10, []float64{1, 1}, []float64{0.01, 0.01},
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, RetryCommand: func(error) bool { return true }}))
+	if err != nil {
+		t.Fatalf("NewI2C() error = %v", err)
+	}
+	bus.setErr(errors.New("bus error"))
+
+	_, _ = dev.GetAlertStatus()
+	if n := bus.readCount(AlertStatusRegister.Address); n != 3 {
+		t.Errorf("ALERT STATUS reads = %d, want 3", n)
+	}
+}
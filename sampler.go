@@ -0,0 +1,161 @@
+package pac194x5x
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Backpressure defines what a Sampler does when the sample channel is full.
+type Backpressure int
+
+const (
+	DropOldest Backpressure = iota // DropOldest - discard the oldest buffered snapshot.
+	Block                          // Block - wait until the consumer receives.
+)
+
+// SamplerConfig configures a Sampler.
+type SamplerConfig struct {
+	Interval     time.Duration   // Interval between refreshes.
+	Delay        time.Duration   // Delay after each refresh. Zero means DefaultDelay.
+	BufferSize   int             // BufferSize of the sample channel.
+	Backpressure Backpressure    // Backpressure policy of the sample channel.
+	OnError      func(err error) // OnError is called when a refresh or read fails. Sampling continues.
+}
+
+// Sampler periodically refreshes a device and publishes snapshots.
+type Sampler struct {
+	dev     *Dev
+	config  SamplerConfig
+	samples chan Snapshot
+
+	mu          sync.Mutex
+	subscribers map[int]func(Snapshot)
+	nextID      int
+	started     bool
+}
+
+// NewSampler creates a Sampler for dev. Interval must be positive, and Delay and BufferSize must not be negative.
+func NewSampler(dev *Dev, config SamplerConfig) (*Sampler, error) {
+	if config.Interval <= 0 {
+		return nil, fmt.Errorf("invalid sampler interval: %v", config.Interval)
+	}
+	if config.Delay < 0 {
+		return nil, fmt.Errorf("invalid sampler delay: %v", config.Delay)
+	}
+	if config.BufferSize < 0 {
+		return nil, fmt.Errorf("invalid sampler buffer size: %d", config.BufferSize)
+	}
+	if config.Delay == 0 {
+		config.Delay = DefaultDelay
+	}
+	return &Sampler{
+		dev:         dev,
+		config:      config,
+		samples:     make(chan Snapshot, config.BufferSize),
+		subscribers: make(map[int]func(Snapshot)),
+	}, nil
+}
+
+// Samples returns the sample channel. It is closed when Run returns.
+func (s *Sampler) Samples() <-chan Snapshot {
+	return s.samples
+}
+
+// Subscribe registers fn to be called with every snapshot and returns a function that removes it.
+// Subscribers are called synchronously from the sampling goroutine.
+func (s *Sampler) Subscribe(fn func(Snapshot)) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.nextID
+	s.nextID++
+	s.subscribers[id] = fn
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.subscribers, id)
+	}
+}
+
+// Run samples until ctx is cancelled. A Sampler can only be run once; further calls return ErrSamplerStarted.
+func (s *Sampler) Run(ctx context.Context) error {
+	s.mu.Lock()
+	started := s.started
+	s.started = true
+	s.mu.Unlock()
+	if started {
+		return ErrSamplerStarted
+	}
+
+	defer close(s.samples)
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		snapshot, err := s.dev.TakeSnapshot(s.config.Delay)
+		if err != nil {
+			if s.config.OnError != nil {
+				s.config.OnError(err)
+			}
+		} else {
+			s.notify(snapshot)
+			if !s.publish(ctx, snapshot) {
+				return ctx.Err()
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Sampler) notify(snapshot Snapshot) {
+	s.mu.Lock()
+	subscribers := make([]func(Snapshot), 0, len(s.subscribers))
+	for _, fn := range s.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	s.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(snapshot)
+	}
+}
+
+// publish sends snapshot to the sample channel and returns false if ctx was cancelled while blocked.
+func (s *Sampler) publish(ctx context.Context, snapshot Snapshot) bool {
+	switch s.config.Backpressure {
+	case Block:
+		select {
+		case s.samples <- snapshot:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	default:
+		if cap(s.samples) == 0 {
+			select {
+			case s.samples <- snapshot:
+			default:
+			}
+			return true
+		}
+		for {
+			select {
+			case s.samples <- snapshot:
+				return true
+			default:
+			}
+			select {
+			case <-s.samples:
+			default:
+			}
+		}
+	}
+}
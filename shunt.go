@@ -0,0 +1,104 @@
+package pac194x5x
+
+import (
+	"periph.io/x/conn/v3/physic"
+)
+
+// TemperatureSource provides the temperature of a shunt resistor.
+type TemperatureSource interface {
+	// Temperature returns the temperature in °C.
+	Temperature() (float64, error)
+}
+
+// TemperatureFunc adapts a function to a TemperatureSource.
+type TemperatureFunc func() (float64, error)
+
+// Temperature returns the temperature in °C.
+func (f TemperatureFunc) Temperature() (float64, error) {
+	return f()
+}
+
+// SenseEnvTemperature adapts a periph environmental sensor to a TemperatureSource.
+func SenseEnvTemperature(sensor physic.SenseEnv) TemperatureSource {
+	return TemperatureFunc(func() (float64, error) {
+		var env physic.Env
+		err := sensor.Sense(&env)
+		if err != nil {
+			return 0, err
+		}
+		return env.Temperature.Celsius(), nil
+	})
+}
+
+// Shunt models a shunt resistor whose resistance drifts with temperature.
+type Shunt struct {
+	Resistance           float64           // Resistance in Ω at ReferenceTemperature.
+	Tempco               float64           // Tempco is the temperature coefficient in ppm/°C.
+	ReferenceTemperature float64           // ReferenceTemperature in °C.
+	Temperature          TemperatureSource // Temperature of the shunt. nil disables compensation.
+}
+
+// ResistanceAt returns the resistance in Ω at temperature in °C.
+func (s Shunt) ResistanceAt(temperature float64) float64 {
+	return s.Resistance * (1 + s.Tempco*1e-6*(temperature-s.ReferenceTemperature))
+}
+
+// SetShunt sets the shunt model of a channel. The shunt resistance is used instead of the channel's rsense value.
+func (dev *Dev) SetShunt(channelNo int, shunt Shunt) error {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	err := dev.checkChannelNo(channelNo)
+	if err != nil {
+		return err
+	}
+	err = checkParameter("rSense", channelNo, shunt.Resistance)
+	if err != nil {
+		return err
+	}
+	if dev.shunts == nil {
+		dev.shunts = make(map[int]Shunt)
+	}
+	dev.shunts[channelNo] = shunt
+	delete(dev.shuntTemps, channelNo)
+	return nil
+}
+
+// getRSense returns the resistance of a channel's shunt in Ω, compensated for temperature if a shunt model
+// with a temperature source is set. The temperature is read once per refresh, so all readings latched by a
+// refresh use the same resistance.
+func (dev *Dev) getRSense(channelNo int) (float64, error) {
+	shunt, ok := dev.shunts[channelNo]
+	if !ok {
+		return dev.rSense[channelNo], nil
+	}
+	if shunt.Temperature == nil {
+		return shunt.Resistance, nil
+	}
+	temperature, ok := dev.shuntTemps[channelNo]
+	if !ok {
+		var err error
+		temperature, err = shunt.Temperature.Temperature()
+		if err != nil {
+			return 0, err
+		}
+		if dev.shuntTemps == nil {
+			dev.shuntTemps = make(map[int]float64)
+		}
+		dev.shuntTemps[channelNo] = temperature
+	}
+	rSense := shunt.ResistanceAt(temperature)
+	err := checkParameter("rSense", channelNo, rSense)
+	if err != nil {
+		return 0, err
+	}
+	return rSense, nil
+}
+
+// invalidate invalidates the cached registers, including the deprecated package-level ones, and the shunt
+// temperatures.
+func (dev *Dev) invalidate() {
+	dev.cache.invalidate()
+	clear(dev.shuntTemps)
+	invalidateCacheRegisters()
+}
@@ -0,0 +1,145 @@
+package pac194x5x
+
+import (
+	"errors"
+	"time"
+)
+
+// ChannelSnapshot holds the measurements of a channel.
+type ChannelSnapshot struct {
+	Channel    int     // Channel number.
+	VBus       float64 // VBus in V.
+	VSense     float64 // VSense in mV.
+	Current    float64 // Current in mA.
+	Power      float64 // Power in W.
+	VBusAvg    float64 // VBusAvg in V.
+	VSenseAvg  float64 // VSenseAvg in mV.
+	CurrentAvg float64 // CurrentAvg in mA.
+	Energy     float64 // Energy in µWh. NaN unless the channel is in VPOWER accumulation mode.
+	Charge     float64 // Charge in mAh. NaN unless the channel is in VSENSE accumulation mode.
+
+	VSenseRange Range // VSenseRange is the latched VSENSE range the values were measured with.
+	VBusRange   Range // VBusRange is the latched VBUS range the values were measured with.
+	Flags       Flags // Flags holds the validity of the values.
+}
+
+// Snapshot holds the measurements of all channels latched by a single refresh.
+type Snapshot struct {
+	Time     time.Time         // Time the values were latched.
+	AccCount uint32            // AccCount is the accumulator count.
+	AccReset uint64            // AccReset counts the accumulator resets sent through the Dev. It changes when the accumulators restart.
+	Channels []ChannelSnapshot // Channels holds the per-channel measurements.
+	Virtual  []VirtualSnapshot // Virtual holds the values of the virtual channels.
+}
+
+// TakeSnapshot sends a Refresh_V command to the device and reads all channels.
+func (dev *Dev) TakeSnapshot(delay time.Duration) (Snapshot, error) {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	t := time.Now()
+	err := dev.RefreshV(delay)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	snapshot, err := dev.ReadSnapshot()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	snapshot.Time = t
+	return snapshot, nil
+}
+
+// ReadSnapshot reads all channels without refreshing the device. Disabled channels are reported with
+// FlagDisabled.
+func (dev *Dev) ReadSnapshot() (Snapshot, error) {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	accCount, err := dev.GetAccCount()
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	snapshot := Snapshot{
+		Time:     time.Now(),
+		AccCount: accCount,
+		AccReset: dev.accResets,
+	}
+	for channelNo := range dev.channelCount {
+		channelSnapshot, err := dev.readChannelSnapshot(channelNo)
+		if err != nil {
+			return Snapshot{}, err
+		}
+		snapshot.Channels = append(snapshot.Channels, channelSnapshot)
+	}
+	virtual, err := dev.evalVirtualChannels(snapshot)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	snapshot.Virtual = virtual
+	return snapshot, nil
+}
+
+func (dev *Dev) readChannelSnapshot(channelNo int) (ChannelSnapshot, error) {
+	err := dev.checkChannelActive(channelNo)
+	if errors.Is(err, ErrChannelDisabled) {
+		return nanChannelSnapshot(channelNo, FlagDisabled), nil
+	}
+	if err != nil {
+		return ChannelSnapshot{}, err
+	}
+
+	cs := ChannelSnapshot{
+		Channel: channelNo,
+	}
+	negPwrFsrLat, err := dev.GetNegPwrFsrLat()
+	if err != nil {
+		return cs, err
+	}
+	cs.VSenseRange = Range(GetField(negPwrFsrLat, VSenseRangeFields[channelNo]))
+	cs.VBusRange = Range(GetField(negPwrFsrLat, VBusRangeFields[channelNo]))
+	cs.VBus, err = dev.GetVBus(channelNo)
+	if err != nil {
+		return cs, err
+	}
+	cs.VSense, err = dev.GetVSense(channelNo)
+	if err != nil {
+		return cs, err
+	}
+	cs.Current, err = dev.GetCurrent(channelNo)
+	if err != nil {
+		return cs, err
+	}
+	cs.Power, err = dev.GetVPower(channelNo)
+	if err != nil {
+		return cs, err
+	}
+	cs.VBusAvg, err = dev.GetVBusAvg(channelNo)
+	if err != nil {
+		return cs, err
+	}
+	cs.VSenseAvg, err = dev.GetVSenseAvg(channelNo)
+	if err != nil {
+		return cs, err
+	}
+	cs.CurrentAvg, err = dev.GetCurrentAvg(channelNo)
+	if err != nil {
+		return cs, err
+	}
+	cs.Energy, err = dev.GetEnergy(channelNo)
+	if err != nil {
+		return cs, err
+	}
+	charge, err := dev.GetCharge(channelNo)
+	if err != nil {
+		return cs, err
+	}
+	cs.Charge = charge.MilliAmpHours
+	cs.Flags, err = dev.channelFlags(channelNo)
+	if err != nil {
+		return cs, err
+	}
+	return cs, nil
+}
@@ -0,0 +1,230 @@
+package pac194x5x
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Quantity identifies a measured quantity of a channel.
+type Quantity int
+
+const (
+	QuantityVoltage Quantity = iota // QuantityVoltage - VBus in V.
+	QuantityCurrent                 // QuantityCurrent - Current in mA.
+	QuantityPower                   // QuantityPower - Power in W.
+	QuantityVSense                  // QuantityVSense - VSense in mV.
+	QuantityEnergy                  // QuantityEnergy - Energy in µWh.
+)
+
+// Summary holds the statistics of a quantity over a time window.
+type Summary struct {
+	Count  int       // Count of samples.
+	From   time.Time // From is the time of the oldest sample.
+	To     time.Time // To is the time of the newest sample.
+	Min    float64   // Min value.
+	Max    float64   // Max value.
+	Mean   float64   // Mean value.
+	RMS    float64   // RMS value.
+	StdDev float64   // StdDev is the population standard deviation.
+	P50    float64   // P50 is the median.
+	P95    float64   // P95 is the 95th percentile.
+	P99    float64   // P99 is the 99th percentile.
+}
+
+// Stats keeps a rolling time window of measurements per channel, group rail and virtual channel.
+//
+// The window is relative to the newest snapshot added, so Stats can be fed from live or recorded snapshots.
+// Stats is safe for concurrent use.
+type Stats struct {
+	window time.Duration
+
+	mu     sync.Mutex
+	latest time.Time
+	series map[seriesKey]*series
+}
+
+type seriesKey struct {
+	channelNo int
+	quantity  Quantity
+	rail      string
+	virtual   string
+}
+
+type sample struct {
+	t time.Time
+	v float64
+}
+
+type series struct {
+	samples []sample
+}
+
+// NewStats creates a Stats with the specified window.
+func NewStats(window time.Duration) *Stats {
+	return &Stats{
+		window: window,
+		series: make(map[seriesKey]*series),
+	}
+}
+
+// Add adds the measurements of snapshot.
+func (s *Stats) Add(snapshot Snapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, cs := range snapshot.Channels {
+		s.addChannel(seriesKey{channelNo: cs.Channel}, snapshot.Time, cs)
+	}
+	s.addVirtual(snapshot.Time, snapshot.Virtual)
+	s.advance(snapshot.Time)
+}
+
+// AddGroup adds the measurements of the rails and the virtual channels of a group snapshot. Rails are summarized
+// by RailSummary, and virtual channels by VirtualSummary.
+func (s *Stats) AddGroup(snapshot GroupSnapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, rs := range snapshot.Rails {
+		s.addChannel(seriesKey{channelNo: -1, rail: rs.Name}, snapshot.Time, rs.ChannelSnapshot)
+	}
+	s.addVirtual(snapshot.Time, snapshot.Virtual)
+	s.advance(snapshot.Time)
+}
+
+// Summary returns the statistics of quantity for channelNo. It returns false if there are no samples.
+func (s *Stats) Summary(channelNo int, quantity Quantity) (Summary, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.summary(seriesKey{channelNo: channelNo, quantity: quantity})
+}
+
+// RailSummary returns the statistics of quantity for the rail name of a group. It returns false if there are no
+// samples.
+func (s *Stats) RailSummary(name string, quantity Quantity) (Summary, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.summary(seriesKey{channelNo: -1, quantity: quantity, rail: name})
+}
+
+// VirtualSummary returns the statistics of the virtual channel name. It returns false if there are no samples.
+func (s *Stats) VirtualSummary(name string) (Summary, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.summary(seriesKey{channelNo: -1, virtual: name})
+}
+
+// Reset discards all samples.
+func (s *Stats) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latest = time.Time{}
+	s.series = make(map[seriesKey]*series)
+}
+
+func (s *Stats) summary(key seriesKey) (Summary, bool) {
+	sr, ok := s.series[key]
+	if !ok || (len(sr.samples) == 0) {
+		return Summary{}, false
+	}
+	return summarize(sr.samples), true
+}
+
+// addChannel adds the quantities of cs to the series identified by key.
+func (s *Stats) addChannel(key seriesKey, t time.Time, cs ChannelSnapshot) {
+	key.quantity = QuantityVoltage
+	s.add(key, t, cs.VBus)
+	key.quantity = QuantityCurrent
+	s.add(key, t, cs.Current)
+	key.quantity = QuantityPower
+	s.add(key, t, cs.Power)
+	key.quantity = QuantityVSense
+	s.add(key, t, cs.VSense)
+	key.quantity = QuantityEnergy
+	s.add(key, t, cs.Energy)
+}
+
+func (s *Stats) addVirtual(t time.Time, virtual []VirtualSnapshot) {
+	for _, vs := range virtual {
+		s.add(seriesKey{channelNo: -1, virtual: vs.Name}, t, vs.Value)
+	}
+}
+
+// advance moves the end of the window to t, unless a newer sample was added before.
+func (s *Stats) advance(t time.Time) {
+	if t.After(s.latest) {
+		s.latest = t
+	}
+	s.prune()
+}
+
+func (s *Stats) add(key seriesKey, t time.Time, v float64) {
+	if math.IsNaN(v) {
+		return
+	}
+	sr, ok := s.series[key]
+	if !ok {
+		sr = &series{}
+		s.series[key] = sr
+	}
+	sr.samples = append(sr.samples, sample{t: t, v: v})
+}
+
+func (s *Stats) prune() {
+	cutoff := s.latest.Add(-s.window)
+	for _, sr := range s.series {
+		i := 0
+		for (i < len(sr.samples)) && sr.samples[i].t.Before(cutoff) {
+			i++
+		}
+		if i > 0 {
+			sr.samples = slices.Delete(sr.samples, 0, i)
+		}
+	}
+}
+
+func summarize(samples []sample) Summary {
+	values := make([]float64, len(samples))
+	summary := Summary{
+		Count: len(samples),
+		From:  samples[0].t,
+		To:    samples[len(samples)-1].t,
+		Min:   math.Inf(1),
+		Max:   math.Inf(-1),
+	}
+	var sum, sumSquares float64
+	for i, smp := range samples {
+		values[i] = smp.v
+		sum += smp.v
+		sumSquares += smp.v * smp.v
+		summary.Min = math.Min(summary.Min, smp.v)
+		summary.Max = math.Max(summary.Max, smp.v)
+	}
+	n := float64(len(samples))
+	summary.Mean = sum / n
+	summary.RMS = math.Sqrt(sumSquares / n)
+	summary.StdDev = math.Sqrt(math.Max(0, sumSquares/n-summary.Mean*summary.Mean))
+
+	slices.Sort(values)
+	summary.P50 = percentile(values, 0.50)
+	summary.P95 = percentile(values, 0.95)
+	summary.P99 = percentile(values, 0.99)
+	return summary
+}
+
+// percentile returns the p-th percentile of sorted values using linear interpolation.
+func percentile(sorted []float64, p float64) float64 {
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower]
+	}
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
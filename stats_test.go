@@ -0,0 +1,57 @@
+package pac194x5x
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestStatsAddGroup(t *testing.T) {
+	bus := newFakeBus(PAC1942_1)
+	dev, err := NewI2C(bus, 0x10, []float64{1, 1}, []float64{0.01, 0.01})
+	if err != nil {
+		t.Fatalf("NewI2C() error = %v", err)
+	}
+	g := NewGroup()
+	for i, name := range []string{"IN", "OUT"} {
+		err = g.AddRail(name, dev, i)
+		if err != nil {
+			t.Fatalf("AddRail() error = %v", err)
+		}
+	}
+	in, err := g.MeasureRail("IN", QuantityVoltage)
+	if err != nil {
+		t.Fatalf("MeasureRail() error = %v", err)
+	}
+	out, err := g.MeasureRail("OUT", QuantityVoltage)
+	if err != nil {
+		t.Fatalf("MeasureRail() error = %v", err)
+	}
+	err = g.AddVirtualChannel("DROP", Difference(in, out))
+	if err != nil {
+		t.Fatalf("AddVirtualChannel() error = %v", err)
+	}
+
+	bus.setRegister(VBus1Register.Address, 0x40, 0x00)
+	bus.setRegister(VBus2Register.Address, 0x30, 0x00)
+	snapshot, err := g.ReadSnapshot()
+	if err != nil {
+		t.Fatalf("ReadSnapshot() error = %v", err)
+	}
+
+	stats := NewStats(time.Minute)
+	stats.AddGroup(snapshot)
+
+	summary, ok := stats.RailSummary("IN", QuantityVoltage)
+	if !ok || (summary.Count != 1) || (summary.Mean != snapshot.Rails[0].VBus) {
+		t.Errorf("RailSummary(IN) = %+v, %v, want mean %v", summary, ok, snapshot.Rails[0].VBus)
+	}
+	want := snapshot.Rails[0].VBus - snapshot.Rails[1].VBus
+	summary, ok = stats.VirtualSummary("DROP")
+	if !ok || (summary.Count != 1) || (math.Abs(summary.Mean-want) > 1e-9) || (want <= 0) {
+		t.Errorf("VirtualSummary(DROP) = %+v, %v, want mean %v", summary, ok, want)
+	}
+	if _, ok := stats.Summary(0, QuantityVoltage); ok {
+		t.Error("Summary(0) has samples from a group snapshot")
+	}
+}
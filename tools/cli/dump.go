@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ngyewch/pac194x5x"
+	"github.com/urfave/cli/v3"
+	"periph.io/x/conn/v3/i2c/i2creg"
+	"periph.io/x/host/v3"
+)
+
+func doDump(ctx context.Context, cmd *cli.Command) error {
+	i2cBus := cmd.String(i2cBusFlag.Name)
+	i2cAddr := cmd.Uint(i2cAddrFlag.Name)
+	voltageRatio := cmd.Float64Slice(voltageRatioFlag.Name)
+	rSense := cmd.Float64Slice(rSenseFlag.Name)
+
+	_, err := host.Init()
+	if err != nil {
+		return err
+	}
+
+	b, err := i2creg.Open(i2cBus)
+	if err != nil {
+		return err
+	}
+
+	dev, err := pac194x5x.NewI2C(b, uint16(i2cAddr), voltageRatio, rSense)
+	if err != nil {
+		return err
+	}
+
+	for _, info := range pac194x5x.Registers() {
+		if info.Access == pac194x5x.AccessCommand {
+			continue
+		}
+		if info.ClearOnRead && !cmd.Bool(clearOnReadFlag.Name) {
+			fmt.Printf("0x%02x %-20s %-2s skipped, cleared on read\n", info.Address, info.Name, info.Access)
+			continue
+		}
+
+		data, err := dev.ReadRegister(info.Address, info.Length)
+		if err != nil {
+			return err
+		}
+
+		var v uint64
+		for _, d := range data {
+			v = (v << 8) | uint64(d)
+		}
+
+		fmt.Printf("0x%02x %-20s %-2s 0x%0*x", info.Address, info.Name, info.Access, info.Length*2, v)
+		if info.Layout != nil {
+			fmt.Printf(" %s", info.Layout.Format(v))
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
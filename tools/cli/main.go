@@ -37,6 +37,10 @@ var (
 		Value:   []float64{0.004, 0.004, 0.004, 0.004},
 		Sources: cli.EnvVars("RSENSE"),
 	}
+	clearOnReadFlag = &cli.BoolFlag{
+		Name:  "clear-on-read",
+		Usage: "also read registers that are cleared on read, e.g. ALERT STATUS",
+	}
 
 	app = &cli.Command{
 		Name:  "pac194x5x",
@@ -53,6 +57,12 @@ var (
 				Usage:  "read",
 				Action: doRead,
 			},
+			{
+				Name:   "dump",
+				Usage:  "dump registers",
+				Flags:  []cli.Flag{clearOnReadFlag},
+				Action: doDump,
+			},
 		},
 	}
 )
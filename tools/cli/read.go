@@ -36,7 +36,12 @@ func doRead(ctx context.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	for i := range dev.Channels() {
+	activeChannels, err := dev.ActiveChannels()
+	if err != nil {
+		return err
+	}
+
+	for _, i := range activeChannels {
 		fmt.Printf("[Channel #%d]\n", i+1)
 
 		vBus, err := dev.GetVBus(i)
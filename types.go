@@ -22,9 +22,13 @@ const (
 type UnitType int
 
 const (
-	Unknown UnitType = iota // Unknown unit type.
-	Volts                   // Volts unit type.
-	Watts                   // Watts unit type.
+	Unknown        UnitType = iota // Unknown unit type.
+	Volts                          // Volts unit type.
+	Watts                          // Watts unit type.
+	MilliVolts                     // MilliVolts unit type.
+	MilliAmperes                   // MilliAmperes unit type.
+	MicroWattHours                 // MicroWattHours unit type.
+	Ratio                          // Ratio unit type, dimensionless.
 )
 
 // SampleMode represents the sample mode.
@@ -45,3 +49,65 @@ const (
 	SampleModeBurst        SampleMode = 11 // SampleModeBurst - Burst mode.
 	SampleModeSleep        SampleMode = 15 //  SampleModeSleep - Sleep.
 )
+
+// Alerts represents the ALERT_STATUS, ALERT_ENABLE, SLOW_ALERT1 and GPIO_ALERT2 register bits.
+type Alerts uint32
+
+const (
+	AlertConversionComplete2 Alerts = 1 << 0  // AlertConversionComplete2 - conversion cycle complete (ALERT2).
+	AlertConversionComplete1 Alerts = 1 << 1  // AlertConversionComplete1 - conversion cycle complete (ALERT1).
+	AlertAccOverflow         Alerts = 1 << 2  // AlertAccOverflow - accumulator overflow.
+	AlertAccCount            Alerts = 1 << 3  // AlertAccCount - accumulator count limit reached.
+	AlertOP4                 Alerts = 1 << 4  // AlertOP4 - channel 4 over power.
+	AlertOP3                 Alerts = 1 << 5  // AlertOP3 - channel 3 over power.
+	AlertOP2                 Alerts = 1 << 6  // AlertOP2 - channel 2 over power.
+	AlertOP1                 Alerts = 1 << 7  // AlertOP1 - channel 1 over power.
+	AlertUV4                 Alerts = 1 << 8  // AlertUV4 - channel 4 under voltage.
+	AlertUV3                 Alerts = 1 << 9  // AlertUV3 - channel 3 under voltage.
+	AlertUV2                 Alerts = 1 << 10 // AlertUV2 - channel 2 under voltage.
+	AlertUV1                 Alerts = 1 << 11 // AlertUV1 - channel 1 under voltage.
+	AlertOV4                 Alerts = 1 << 12 // AlertOV4 - channel 4 over voltage.
+	AlertOV3                 Alerts = 1 << 13 // AlertOV3 - channel 3 over voltage.
+	AlertOV2                 Alerts = 1 << 14 // AlertOV2 - channel 2 over voltage.
+	AlertOV1                 Alerts = 1 << 15 // AlertOV1 - channel 1 over voltage.
+	AlertUC4                 Alerts = 1 << 16 // AlertUC4 - channel 4 under current.
+	AlertUC3                 Alerts = 1 << 17 // AlertUC3 - channel 3 under current.
+	AlertUC2                 Alerts = 1 << 18 // AlertUC2 - channel 2 under current.
+	AlertUC1                 Alerts = 1 << 19 // AlertUC1 - channel 1 under current.
+	AlertOC4                 Alerts = 1 << 20 // AlertOC4 - channel 4 over current.
+	AlertOC3                 Alerts = 1 << 21 // AlertOC3 - channel 3 over current.
+	AlertOC2                 Alerts = 1 << 22 // AlertOC2 - channel 2 over current.
+	AlertOC1                 Alerts = 1 << 23 // AlertOC1 - channel 1 over current.
+)
+
+// AlertType represents the kind of alert.
+type AlertType int
+
+const (
+	AlertTypeOverCurrent        AlertType = iota // AlertTypeOverCurrent - over current.
+	AlertTypeUnderCurrent                        // AlertTypeUnderCurrent - under current.
+	AlertTypeOverVoltage                         // AlertTypeOverVoltage - over voltage.
+	AlertTypeUnderVoltage                        // AlertTypeUnderVoltage - under voltage.
+	AlertTypeOverPower                           // AlertTypeOverPower - over power.
+	AlertTypeAccCount                            // AlertTypeAccCount - accumulator count limit reached.
+	AlertTypeAccOverflow                         // AlertTypeAccOverflow - accumulator overflow.
+	AlertTypeConversionComplete                  // AlertTypeConversionComplete - conversion cycle complete.
+)
+
+// AccumMode represents the accumulation mode of a channel in the ACCUM_CONFIG register.
+type AccumMode uint8
+
+const (
+	AccumModeVPower AccumMode = 0 // AccumModeVPower - accumulate VPOWER (energy).
+	AccumModeVSense AccumMode = 1 // AccumModeVSense - accumulate VSENSE (charge).
+	AccumModeVBus   AccumMode = 2 // AccumModeVBus - accumulate VBUS.
+)
+
+// Range represents the full-scale range of a channel's VSENSE or VBUS in the NEG_PWR_FSR register.
+type Range uint16
+
+const (
+	RangeUnipolar    Range = 0 // RangeUnipolar - unipolar, 0 to full scale.
+	RangeBipolar     Range = 1 // RangeBipolar - bipolar, -full scale to full scale.
+	RangeBipolarHalf Range = 2 // RangeBipolarHalf - bipolar, -full scale/2 to full scale/2.
+)
@@ -0,0 +1,234 @@
+package pac194x5x
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Expr is an expression over the channel measurements of a snapshot.
+type Expr interface {
+	// Eval evaluates the expression against snapshot and returns the value and its unit.
+	Eval(snapshot Snapshot) (float64, UnitType, error)
+}
+
+// VirtualChannel is a named expression evaluated on every snapshot.
+type VirtualChannel struct {
+	Name string // Name of virtual channel, e.g. "LOSS".
+	Expr Expr   // Expr is the expression to evaluate.
+}
+
+// VirtualSnapshot holds the value of a virtual channel.
+type VirtualSnapshot struct {
+	Name  string   // Name of virtual channel.
+	Value float64  // Value of the expression.
+	Unit  UnitType // Unit of the value.
+}
+
+// Measure returns an expression that reads quantity of channelNo.
+func Measure(channelNo int, quantity Quantity) Expr {
+	return measureExpr{channelNo: channelNo, quantity: quantity}
+}
+
+// Sum returns an expression that adds terms. All terms must have the same unit.
+func Sum(terms ...Expr) Expr {
+	return sumExpr{terms: terms}
+}
+
+// Difference returns an expression that subtracts b from a, e.g. converter loss. Both must have the same unit.
+func Difference(a Expr, b Expr) Expr {
+	return differenceExpr{a: a, b: b}
+}
+
+// RatioOf returns an expression that divides a by b, e.g. converter efficiency. The result is a Ratio if both
+// have the same unit, and Unknown otherwise.
+func RatioOf(a Expr, b Expr) Expr {
+	return ratioExpr{a: a, b: b}
+}
+
+type measureExpr struct {
+	channelNo int
+	quantity  Quantity
+}
+
+func (e measureExpr) Eval(snapshot Snapshot) (float64, UnitType, error) {
+	for _, cs := range snapshot.Channels {
+		if cs.Channel != e.channelNo {
+			continue
+		}
+		switch e.quantity {
+		case QuantityVoltage:
+			return cs.VBus, Volts, nil
+		case QuantityCurrent:
+			return cs.Current, MilliAmperes, nil
+		case QuantityPower:
+			return cs.Power, Watts, nil
+		case QuantityVSense:
+			return cs.VSense, MilliVolts, nil
+		case QuantityEnergy:
+			return cs.Energy, MicroWattHours, nil
+		default:
+			return 0, Unknown, fmt.Errorf("unknown quantity: %d", e.quantity)
+		}
+	}
+	return 0, Unknown, fmt.Errorf("%w: %d not in snapshot", ErrInvalidChannel, e.channelNo)
+}
+
+type sumExpr struct {
+	terms []Expr
+}
+
+func (e sumExpr) Eval(snapshot Snapshot) (float64, UnitType, error) {
+	var sum float64
+	unit := Unknown
+	for i, term := range e.terms {
+		v, u, err := term.Eval(snapshot)
+		if err != nil {
+			return 0, Unknown, err
+		}
+		if i == 0 {
+			unit = u
+		} else if u != unit {
+			return 0, Unknown, fmt.Errorf("unit mismatch: %d and %d", unit, u)
+		}
+		sum += v
+	}
+	return sum, unit, nil
+}
+
+type differenceExpr struct {
+	a Expr
+	b Expr
+}
+
+func (e differenceExpr) Eval(snapshot Snapshot) (float64, UnitType, error) {
+	a, unitA, err := e.a.Eval(snapshot)
+	if err != nil {
+		return 0, Unknown, err
+	}
+	b, unitB, err := e.b.Eval(snapshot)
+	if err != nil {
+		return 0, Unknown, err
+	}
+	if unitA != unitB {
+		return 0, Unknown, fmt.Errorf("unit mismatch: %d and %d", unitA, unitB)
+	}
+	return a - b, unitA, nil
+}
+
+type ratioExpr struct {
+	a Expr
+	b Expr
+}
+
+func (e ratioExpr) Eval(snapshot Snapshot) (float64, UnitType, error) {
+	a, unitA, err := e.a.Eval(snapshot)
+	if err != nil {
+		return 0, Unknown, err
+	}
+	b, unitB, err := e.b.Eval(snapshot)
+	if err != nil {
+		return 0, Unknown, err
+	}
+	unit := Unknown
+	if unitA == unitB {
+		unit = Ratio
+	}
+	if b == 0 {
+		return math.NaN(), unit, nil
+	}
+	return a / b, unit, nil
+}
+
+// AddVirtualChannel adds a virtual channel that is evaluated on every snapshot read from the device. The
+// expression is checked against the channels of the device, so invalid channel numbers and unit mismatches are
+// reported here rather than by every later snapshot.
+func (dev *Dev) AddVirtualChannel(name string, expr Expr) error {
+	dev, unlock := dev.acquire()
+	defer unlock()
+
+	virtualChannels, err := addVirtualChannel(dev.virtualChannels, name, expr, dev.channelCount)
+	if err != nil {
+		return err
+	}
+	dev.virtualChannels = virtualChannels
+	return nil
+}
+
+// evalVirtualChannels evaluates the virtual channels against snapshot.
+func (dev *Dev) evalVirtualChannels(snapshot Snapshot) ([]VirtualSnapshot, error) {
+	return evalVirtualChannels(dev.virtualChannels, snapshot)
+}
+
+// addVirtualChannel checks expr against a snapshot of channelCount channels and appends it to virtualChannels.
+func addVirtualChannel(virtualChannels []VirtualChannel, name string, expr Expr, channelCount int) ([]VirtualChannel, error) {
+	for _, vc := range virtualChannels {
+		if vc.Name == name {
+			return nil, fmt.Errorf("duplicate virtual channel name: %s", name)
+		}
+	}
+
+	probe := Snapshot{}
+	for channelNo := range channelCount {
+		probe.Channels = append(probe.Channels, ChannelSnapshot{
+			Channel:    channelNo,
+			VBus:       1,
+			VSense:     1,
+			Current:    1,
+			Power:      1,
+			VBusAvg:    1,
+			VSenseAvg:  1,
+			CurrentAvg: 1,
+			Energy:     1,
+		})
+	}
+	_, _, err := expr.Eval(probe)
+	if err != nil {
+		return nil, fmt.Errorf("virtual channel %s: %w", name, err)
+	}
+
+	return append(virtualChannels, VirtualChannel{Name: name, Expr: expr}), nil
+}
+
+func evalVirtualChannels(virtualChannels []VirtualChannel, snapshot Snapshot) ([]VirtualSnapshot, error) {
+	var virtual []VirtualSnapshot
+	for _, vc := range virtualChannels {
+		v, unit, err := vc.Expr.Eval(snapshot)
+		if err != nil {
+			return nil, fmt.Errorf("virtual channel %s: %w", vc.Name, err)
+		}
+		virtual = append(virtual, VirtualSnapshot{Name: vc.Name, Value: v, Unit: unit})
+	}
+	return virtual, nil
+}
+
+// VirtualLimit defines the allowed range of a virtual channel. A NaN bound is not checked.
+type VirtualLimit struct {
+	Name string  // Name of virtual channel.
+	Min  float64 // Min value.
+	Max  float64 // Max value.
+}
+
+// VirtualAlert records a virtual channel outside its limit.
+type VirtualAlert struct {
+	Time  time.Time    // Time of the snapshot.
+	Value float64      // Value of the virtual channel.
+	Limit VirtualLimit // Limit that was exceeded.
+}
+
+// CheckVirtualLimits returns an alert for every virtual channel of virtual outside its limit. It can be called
+// from a Sampler subscriber with Snapshot.Virtual or with GroupSnapshot.Virtual. NaN values raise no alert.
+func CheckVirtualLimits(t time.Time, virtual []VirtualSnapshot, limits []VirtualLimit) []VirtualAlert {
+	var alerts []VirtualAlert
+	for _, limit := range limits {
+		for _, vs := range virtual {
+			if vs.Name != limit.Name {
+				continue
+			}
+			if (vs.Value < limit.Min) || (vs.Value > limit.Max) {
+				alerts = append(alerts, VirtualAlert{Time: t, Value: vs.Value, Limit: limit})
+			}
+		}
+	}
+	return alerts
+}